@@ -0,0 +1,141 @@
+package wordpress
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ApplicationPassword represents a WordPress application password (WordPress 5.6+).
+type ApplicationPassword struct {
+	UUID     string `json:"uuid,omitempty"`
+	AppID    string `json:"app_id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Password string `json:"password,omitempty"` // Only returned when the password is created.
+	Created  *Time  `json:"created,omitempty"`
+	LastUsed *Time  `json:"last_used,omitempty"`
+	LastIP   string `json:"last_ip,omitempty"`
+}
+
+// ApplicationPasswordsService provides access to the application passwords of a single user.
+type ApplicationPasswordsService struct {
+	client *Client
+	url    string
+}
+
+// ApplicationPasswords returns the application passwords service of the user with the given id.
+func (c *UsersService) ApplicationPasswords(userID int) *ApplicationPasswordsService {
+	return &ApplicationPasswordsService{
+		client: c.Client,
+		url:    fmt.Sprintf("users/%v/application-passwords", userID),
+	}
+}
+
+// MeApplicationPasswords returns the application passwords service of the currently authenticated user.
+func (c *UsersService) MeApplicationPasswords() *ApplicationPasswordsService {
+	return &ApplicationPasswordsService{
+		client: c.Client,
+		url:    "users/me/application-passwords",
+	}
+}
+
+// List returns a list of application passwords.
+func (c *ApplicationPasswordsService) List(ctx context.Context, params interface{}) ([]*ApplicationPassword, *Response, error) {
+	passwords := []*ApplicationPassword{}
+	resp, err := c.client.List(ctx, c.url, params, &passwords)
+	return passwords, resp, err
+}
+
+// Create creates a new application password. The generated password is only
+// available in the Password field of the returned application password.
+func (c *ApplicationPasswordsService) Create(ctx context.Context, newPassword *ApplicationPassword) (*ApplicationPassword, *Response, error) {
+	var created ApplicationPassword
+	resp, err := c.client.Create(ctx, c.url, newPassword, &created)
+	return &created, resp, err
+}
+
+// Get returns a single application password for the given uuid.
+func (c *ApplicationPasswordsService) Get(ctx context.Context, uuid string, params interface{}) (*ApplicationPassword, *Response, error) {
+	var entity ApplicationPassword
+	entityURL := fmt.Sprintf("%v/%v", c.url, uuid)
+	resp, err := c.client.Get(ctx, entityURL, params, &entity)
+	return &entity, resp, err
+}
+
+// Introspect returns the application password used to authenticate the current request.
+func (c *ApplicationPasswordsService) Introspect(ctx context.Context, params interface{}) (*ApplicationPassword, *Response, error) {
+	var entity ApplicationPassword
+	entityURL := fmt.Sprintf("%v/introspect", c.url)
+	resp, err := c.client.Get(ctx, entityURL, params, &entity)
+	return &entity, resp, err
+}
+
+// Update updates a single application password with the given uuid. Only the name can be changed.
+func (c *ApplicationPasswordsService) Update(ctx context.Context, uuid string, password *ApplicationPassword) (*ApplicationPassword, *Response, error) {
+	var updated ApplicationPassword
+	entityURL := fmt.Sprintf("%v/%v", c.url, uuid)
+	resp, err := c.client.Update(ctx, entityURL, password, &updated)
+	return &updated, resp, err
+}
+
+// Delete revokes the application password with the given uuid.
+func (c *ApplicationPasswordsService) Delete(ctx context.Context, uuid string) (*ApplicationPassword, *Response, error) {
+	var deleted ApplicationPassword
+	entityURL := fmt.Sprintf("%v/%v", c.url, uuid)
+
+	req, err := c.client.NewRequest("DELETE", entityURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var deleteResp DeleteResponse
+	resp, err := c.client.Do(ctx, req, &deleteResp)
+	if err != nil {
+		return &deleted, resp, err
+	}
+	if deleteResp.Deleted && len(deleteResp.Previous) > 0 {
+		if err := json.Unmarshal(deleteResp.Previous, &deleted); err != nil {
+			return &deleted, resp, err
+		}
+		fillExtra(deleteResp.Previous, &deleted)
+	}
+	return &deleted, resp, nil
+}
+
+// DeleteAll revokes all application passwords of the user and returns the number of revoked passwords.
+func (c *ApplicationPasswordsService) DeleteAll(ctx context.Context) (int, *Response, error) {
+	req, err := c.client.NewRequest("DELETE", c.url, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var deleteResp struct {
+		Deleted bool `json:"deleted"`
+		Count   int  `json:"count"`
+	}
+	resp, err := c.client.Do(ctx, req, &deleteResp)
+	return deleteResp.Count, resp, err
+}
+
+// Rotate replaces the application password used to authenticate the current
+// request. It creates a new password with the same name and app id, then
+// revokes the current one. The returned application password holds the new
+// password, which must be used for all further requests. If revoking the
+// current password fails, the new password is returned along with the error.
+func (c *ApplicationPasswordsService) Rotate(ctx context.Context) (*ApplicationPassword, *Response, error) {
+	current, resp, err := c.Introspect(ctx, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	created, resp, err := c.Create(ctx, &ApplicationPassword{
+		Name:  current.Name,
+		AppID: current.AppID,
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	_, resp, err = c.Delete(ctx, current.UUID)
+	return created, resp, err
+}
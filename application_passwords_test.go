@@ -0,0 +1,74 @@
+package wordpress_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const testApplicationPassword = `{"uuid":"6f5ec1d1-0c2b-4f3e-a5a1-3c7a0e2f7b1d","app_id":"","name":"sync-bot","created":"2021-03-01T10:00:00","last_used":null,"last_ip":null}`
+
+func TestApplicationPasswords_Rotate(t *testing.T) {
+	var deleted, createdName string
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /wp-json/wp/v2/users/me/application-passwords/introspect":
+			w.Write([]byte(testApplicationPassword))
+		case "POST /wp-json/wp/v2/users/me/application-passwords":
+			var body struct {
+				Name string `json:"name"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			createdName = body.Name
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"uuid":"new-uuid","app_id":"","name":"sync-bot","created":"2021-04-01T10:00:00","last_used":null,"last_ip":null,"password":"abcd efgh ijkl mnop qrst uvwx"}`))
+		case "DELETE /wp-json/wp/v2/users/me/application-passwords/6f5ec1d1-0c2b-4f3e-a5a1-3c7a0e2f7b1d":
+			deleted = "6f5ec1d1-0c2b-4f3e-a5a1-3c7a0e2f7b1d"
+			w.Write([]byte(`{"deleted":true,"previous":` + testApplicationPassword + `}`))
+		default:
+			t.Errorf("Unexpected request %v %v", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	created, _, err := wp.Users.MeApplicationPasswords().Rotate(ctx)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if created.UUID != "new-uuid" || created.Password == "" {
+		t.Errorf("Expected new password, got %+v", created)
+	}
+	if createdName != "sync-bot" {
+		t.Errorf("New password should keep the name, got %q", createdName)
+	}
+	if deleted == "" {
+		t.Errorf("Current password should be revoked")
+	}
+}
+
+func TestApplicationPasswords_ListAndDeleteAll(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wp-json/wp/v2/users/3/application-passwords" {
+			t.Errorf("Unexpected request %v %v", r.Method, r.URL.Path)
+		}
+		if r.Method == "DELETE" {
+			w.Write([]byte(`{"deleted":true,"count":1}`))
+			return
+		}
+		w.Write([]byte(`[` + testApplicationPassword + `]`))
+	})
+
+	service := wp.Users.ApplicationPasswords(3)
+	passwords, _, err := service.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if len(passwords) != 1 || passwords[0].Name != "sync-bot" || passwords[0].LastUsed != nil || passwords[0].Created.Year() != 2021 {
+		t.Errorf("Unexpected application passwords: %+v", passwords)
+	}
+
+	count, _, err := service.DeleteAll(ctx)
+	if err != nil || count != 1 {
+		t.Errorf("Expected 1 revoked password, got %v (%v)", count, err)
+	}
+}
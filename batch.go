@@ -0,0 +1,372 @@
+package wordpress
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBatchSize is the maximum number of requests WordPress accepts in a single batch request.
+const DefaultBatchSize = 25
+
+// ErrBatchAborted is the error of batch operations that were not executed
+// because another operation of the same batch request failed validation.
+var ErrBatchAborted = errors.New("batch aborted: another request failed validation")
+
+// Batch collects create, update and delete operations and sends them through
+// the batch endpoint of WordPress 5.6+ (/batch/v1), DefaultBatchSize
+// operations per request. Operations are queued with the typed collections,
+// e.g.
+//
+//	b := client.Batch()
+//	update := b.Posts.Update(5, &wordpress.Post{Tags: []int{7}})
+//	create := b.Tags.Create(&wordpress.Tag{Name: "news"})
+//	remove := b.Tags.Delete(8, "force=true")
+//	_, err := b.Submit(ctx)
+//
+// after which every operation holds either its Result or its Err.
+//
+// Only routes registered with batch support can be used; in WordPress core
+// these are the posts, pages, custom post types and terms routes. Use
+// NewBatchCollection for other routes.
+type Batch struct {
+	// RequireAllValidate makes WordPress validate all operations of a batch
+	// request before executing any of them. If one of them is invalid, none is
+	// executed: the invalid operations get their validation error and the
+	// others ErrBatchAborted. This applies per batch request, so with more
+	// than Size operations, earlier requests may already have been executed;
+	// later requests are not sent.
+	RequireAllValidate bool
+
+	// Size is the maximum number of operations sent in a single batch request.
+	// Defaults to DefaultBatchSize.
+	Size int
+
+	Categories *BatchCollection[Category]
+	Pages      *BatchCollection[Page]
+	Posts      *BatchCollection[Post]
+	Tags       *BatchCollection[Tag]
+
+	client *Client
+	ops    []batchOperation
+}
+
+// Batch returns an empty Batch sending its operations with c.
+func (c *Client) Batch() *Batch {
+	b := &Batch{client: c}
+	b.Categories = NewBatchCollection[Category](b, "categories")
+	b.Pages = NewBatchCollection[Page](b, "pages")
+	b.Posts = NewBatchCollection[Post](b, "posts")
+	b.Tags = NewBatchCollection[Tag](b, "tags")
+	return b
+}
+
+// Len returns the number of queued operations.
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+// BatchCollection queues operations on the entities of a single wp/v2 route.
+type BatchCollection[T any] struct {
+	batch *Batch
+	url   string
+}
+
+// NewBatchCollection returns a BatchCollection queuing operations on the wp/v2
+// route url in b, e.g. a custom post type or taxonomy.
+func NewBatchCollection[T any](b *Batch, url string) *BatchCollection[T] {
+	return &BatchCollection[T]{batch: b, url: url}
+}
+
+// Create queues the creation of entity.
+func (c *BatchCollection[T]) Create(entity *T) *BatchOp[T] {
+	return addBatchOp[T](c.batch, "POST", c.url, "", entity)
+}
+
+// Update queues the update of the entity with the given id.
+func (c *BatchCollection[T]) Update(id int, entity *T) *BatchOp[T] {
+	return addBatchOp[T](c.batch, "PUT", fmt.Sprintf("%v/%v", c.url, id), "", entity)
+}
+
+// Delete queues the deletion of the entity with the given id. As with the
+// Delete methods of the services, the deleted entity is returned as Result
+// when params contain force=true.
+func (c *BatchCollection[T]) Delete(id int, params interface{}) *BatchOp[T] {
+	qs, err := encodeOptions(params)
+	op := addBatchOp[T](c.batch, "DELETE", fmt.Sprintf("%v/%v", c.url, id), qs, nil)
+	if err != nil {
+		op.Err = err
+	}
+	return op
+}
+
+// BatchOp is a single operation of a Batch. Once the batch has been
+// submitted, it holds either the decoded Result or the Err of the operation.
+type BatchOp[T any] struct {
+	Result *T
+
+	// Response is the response of the operation, rebuilt from the status and
+	// headers returned by the batch endpoint. It is nil if the operation was not executed.
+	Response *Response
+
+	// Err is the error of the operation, usually an *Error from WordPress.
+	Err error
+
+	request batchRequest
+	force   bool
+}
+
+// batchOperation is the type independent part of a BatchOp.
+type batchOperation interface {
+	batchRequest() *batchRequest
+	failed() bool
+	resolve(resp *Response, body json.RawMessage, err error)
+}
+
+// batchRequest is a single request in the body of a batch request.
+type batchRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Body   interface{} `json:"body,omitempty"`
+
+	route string // Path without the query
+	query string
+}
+
+// batchResponse is a single response in the body of a batch response.
+type batchResponse struct {
+	Body    json.RawMessage            `json:"body"`
+	Status  int                        `json:"status"`
+	Headers map[string]json.RawMessage `json:"headers"`
+}
+
+func addBatchOp[T any](b *Batch, method, urlStr, qs string, body interface{}) *BatchOp[T] {
+	route := fmt.Sprintf("%s/%s", apiPathPrefix, urlStr)
+	path := route
+	if qs != "" {
+		path += "?" + qs
+	}
+	op := &BatchOp[T]{
+		request: batchRequest{Method: method, Path: path, Body: body, route: route, query: qs},
+	}
+	if q, err := url.ParseQuery(qs); err == nil && isForced(q) {
+		op.force = true
+	}
+	b.ops = append(b.ops, op)
+	return op
+}
+
+func (op *BatchOp[T]) batchRequest() *batchRequest {
+	return &op.request
+}
+
+func (op *BatchOp[T]) failed() bool {
+	return op.Err != nil
+}
+
+func (op *BatchOp[T]) resolve(resp *Response, body json.RawMessage, err error) {
+	op.Response = resp
+	if err != nil {
+		op.Err = err
+		return
+	}
+
+	if op.force {
+		var deleteResp DeleteResponse
+		if err := json.Unmarshal(body, &deleteResp); err != nil {
+			op.Err = err
+			return
+		}
+		if !deleteResp.Deleted || len(deleteResp.Previous) == 0 {
+			return
+		}
+		body = deleteResp.Previous
+	}
+
+	var result T
+	if err := json.Unmarshal(body, &result); err != nil {
+		op.Err = err
+		return
+	}
+	fillExtra(body, &result)
+	op.Result = &result
+}
+
+// Submit sends all queued operations and empties the batch. It returns the
+// responses of the batch requests, one per Size operations. The returned
+// error only reports batch requests that failed as a whole, in which case the
+// operations of that request and all later ones get the error too; the
+// outcome of each operation is reported by the operation itself.
+func (b *Batch) Submit(ctx context.Context) ([]*Response, error) {
+	ops := b.ops
+	b.ops = nil
+
+	size := b.Size
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+
+	var responses []*Response
+	for start := 0; start < len(ops); start += size {
+		end := start + size
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		resp, aborted, err := b.submitChunk(ctx, ops[start:end])
+		if resp != nil {
+			responses = append(responses, resp)
+		}
+		if err != nil || aborted {
+			for _, op := range ops[end:] {
+				if err == nil {
+					op.resolve(nil, nil, ErrBatchAborted)
+				} else {
+					op.resolve(nil, nil, err)
+				}
+			}
+			return responses, err
+		}
+	}
+	return responses, nil
+}
+
+// submitChunk sends a single batch request. aborted reports whether it failed validation.
+func (b *Batch) submitChunk(ctx context.Context, ops []batchOperation) (*Response, bool, error) {
+	body := struct {
+		Validation string          `json:"validation,omitempty"`
+		Requests   []*batchRequest `json:"requests"`
+	}{}
+	if b.RequireAllValidate {
+		body.Validation = "require-all-validate"
+	}
+
+	// operations which failed before sending, e.g. on encoding their params, are left out
+	var sent []batchOperation
+	for _, op := range ops {
+		if op.failed() {
+			continue
+		}
+		request, err := b.client.withExtraFields(op.batchRequest())
+		if err != nil {
+			op.resolve(nil, nil, err)
+			continue
+		}
+		body.Requests = append(body.Requests, request)
+		sent = append(sent, op)
+	}
+	if len(sent) == 0 {
+		return nil, false, nil
+	}
+
+	u, err := b.client.getRouteURL("/batch/v1")
+	if err != nil {
+		return nil, false, err
+	}
+	req, err := b.client.newRequest("POST", u, body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var result struct {
+		Failed    string           `json:"failed"`
+		Responses []*batchResponse `json:"responses"`
+	}
+	resp, err := b.client.Do(ctx, req, &result)
+	if err == nil && len(result.Responses) != len(sent) {
+		err = fmt.Errorf("batch returned %d responses for %d requests", len(result.Responses), len(sent))
+	}
+	if err != nil {
+		for _, op := range sent {
+			op.resolve(nil, nil, err)
+		}
+		return resp, false, err
+	}
+
+	for i, op := range sent {
+		subResp := result.Responses[i]
+		if subResp == nil {
+			op.resolve(nil, nil, ErrBatchAborted)
+			continue
+		}
+		opResp, opErr := b.client.batchOpResponse(op.batchRequest(), subResp)
+		op.resolve(opResp, subResp.Body, opErr)
+	}
+
+	if b.client.Cache != nil {
+		for _, op := range sent {
+			b.client.Cache.invalidate(cacheRoute(&url.URL{Path: "/wp-json" + op.batchRequest().route}))
+		}
+	}
+	return resp, result.Failed == "validation", nil
+}
+
+// withExtraFields returns req with the ExtraFields of its body merged into
+// it if Client.SendExtraFields is set, as newRequest does for single requests.
+func (c *Client) withExtraFields(req *batchRequest) (*batchRequest, error) {
+	if !c.SendExtraFields || req.Body == nil {
+		return req, nil
+	}
+	var encoded bytes.Buffer
+	enc := json.NewEncoder(&encoded)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(req.Body); err != nil {
+		return nil, err
+	}
+	data, err := mergeExtra(encoded.Bytes(), req.Body)
+	if err != nil {
+		return nil, err
+	}
+	merged := *req
+	merged.Body = json.RawMessage(data)
+	return &merged, nil
+}
+
+// batchOpResponse rebuilds the Response of a single operation from its part of
+// the batch response, and returns its error, if any.
+func (c *Client) batchOpResponse(req *batchRequest, r *batchResponse) (*Response, error) {
+	u, err := c.getRouteURL(req.route)
+	if err != nil {
+		return nil, err
+	}
+	if req.query != "" {
+		if u.RawQuery != "" {
+			u.RawQuery += "&"
+		}
+		u.RawQuery += req.query
+	}
+	httpReq := &http.Request{Method: req.Method, URL: u, Header: make(http.Header), Host: u.Host}
+
+	header := make(http.Header, len(r.Headers))
+	for name, raw := range r.Headers {
+		var value string
+		var values []string
+		if json.Unmarshal(raw, &value) == nil {
+			header.Set(name, value)
+		} else if json.Unmarshal(raw, &values) == nil {
+			header[http.CanonicalHeaderKey(name)] = values
+		}
+	}
+
+	httpResp := &http.Response{
+		Status:     fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode: r.Status,
+		Header:     header,
+		Request:    httpReq,
+	}
+	resp := newResponse(httpResp)
+
+	if r.Status >= 200 && r.Status <= 299 {
+		return resp, nil
+	}
+	apiErr := &Error{Response: httpResp}
+	if err := json.Unmarshal(r.Body, apiErr); err != nil || apiErr.Code == "" {
+		apiErr.Message = strings.TrimSpace(http.StatusText(r.Status))
+	}
+	return resp, apiErr
+}
@@ -0,0 +1,179 @@
+package wordpress_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+type stubBatchRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body"`
+}
+
+type stubBatchBody struct {
+	Validation string             `json:"validation"`
+	Requests   []stubBatchRequest `json:"requests"`
+}
+
+func TestBatch_Submit(t *testing.T) {
+	var batches []stubBatchBody
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wp-json/batch/v1" || r.Method != "POST" {
+			t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+		}
+		var body stubBatchBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode batch body: %v", err)
+		}
+		batches = append(batches, body)
+
+		var responses []string
+		for _, req := range body.Requests {
+			switch {
+			case req.Method == "PUT":
+				responses = append(responses, `{"status":200,"headers":{"Allow":"GET, POST"},"body":{"id":5,"tags":[7]}}`)
+			case req.Method == "POST":
+				responses = append(responses, `{"status":400,"headers":{},"body":{"code":"term_exists","message":"A term with the name provided already exists.","data":{"status":400}}}`)
+			case req.Method == "DELETE":
+				responses = append(responses, `{"status":200,"headers":{},"body":{"deleted":true,"previous":{"id":8,"name":"old"}}}`)
+			}
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		fmt.Fprintf(w, `{"responses":[%s]}`, strings.Join(responses, ","))
+	})
+
+	b := wp.Batch()
+	b.Size = 2
+	update := b.Posts.Update(5, &wordpress.Post{Tags: []int{7}})
+	create := b.Tags.Create(&wordpress.Tag{Name: "news"})
+	remove := b.Tags.Delete(8, "force=true")
+
+	responses, err := b.Submit(ctx)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if len(responses) != 2 || len(batches) != 2 {
+		t.Fatalf("Expected 2 batch requests, got %d", len(batches))
+	}
+	if got := batches[0].Requests[0]; got.Path != "/wp/v2/posts/5" || string(got.Body) != `{"tags":[7]}` {
+		t.Errorf("Unexpected sub-request %+v (%s)", got, got.Body)
+	}
+	if got := batches[1].Requests[0]; got.Method != "DELETE" || got.Path != "/wp/v2/tags/8?force=true" {
+		t.Errorf("Unexpected sub-request %+v", got)
+	}
+	if b.Len() != 0 {
+		t.Errorf("Expected batch to be emptied, got %d operations", b.Len())
+	}
+
+	if update.Err != nil || update.Result == nil || update.Result.ID != 5 {
+		t.Errorf("Unexpected update result %+v (%v)", update.Result, update.Err)
+	}
+	if update.Response.StatusCode != 200 || update.Response.Header.Get("Allow") != "GET, POST" {
+		t.Errorf("Unexpected update response %+v", update.Response)
+	}
+
+	var apiErr *wordpress.Error
+	if !errors.As(create.Err, &apiErr) || apiErr.Code != "term_exists" || !errors.Is(create.Err, wordpress.ErrConflict) {
+		t.Errorf("Expected term_exists error, got %v", create.Err)
+	}
+	if create.Result != nil {
+		t.Errorf("Expected no result for failed operation, got %+v", create.Result)
+	}
+
+	if remove.Err != nil || remove.Result == nil || remove.Result.Name != "old" {
+		t.Errorf("Expected previous tag as result, got %+v (%v)", remove.Result, remove.Err)
+	}
+}
+
+func TestBatch_RequireAllValidate(t *testing.T) {
+	var requests int
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body stubBatchBody
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Validation != "require-all-validate" {
+			t.Errorf("Expected validation mode, got %q", body.Validation)
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(`{"failed":"validation","responses":[null,{"status":400,"headers":{},"body":{"code":"rest_invalid_param","message":"Invalid parameter(s): status","data":{"status":400,"params":{"status":"status is not one of publish, draft."}}}}]}`))
+	})
+
+	b := wp.Batch()
+	b.RequireAllValidate = true
+	b.Size = 2
+	valid := b.Posts.Update(1, &wordpress.Post{Title: wordpress.RenderedString{Raw: "ok"}})
+	invalid := b.Posts.Update(2, &wordpress.Post{Status: "bogus"})
+	notSent := b.Pages.Create(&wordpress.Page{})
+
+	if _, err := b.Submit(ctx); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected later batch requests to be skipped, got %d requests", requests)
+	}
+	if valid.Err != wordpress.ErrBatchAborted || notSent.Err != wordpress.ErrBatchAborted {
+		t.Errorf("Expected aborted operations, got %v and %v", valid.Err, notSent.Err)
+	}
+	if !errors.Is(invalid.Err, wordpress.ErrInvalidParam) {
+		t.Errorf("Expected validation error, got %v", invalid.Err)
+	}
+}
+
+func TestBatch_SendExtraFields(t *testing.T) {
+	var body stubBatchBody
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(`{"responses":[{"status":201,"headers":{},"body":{"id":9}},{"status":200,"headers":{},"body":{"id":5}}]}`))
+	})
+	wp.SendExtraFields = true
+
+	b := wp.Batch()
+	b.Tags.Create(&wordpress.Tag{Name: "news", Extra: wordpress.ExtraFields{"wpml_language": json.RawMessage(`"de"`)}})
+	b.Posts.Update(5, &wordpress.Post{Tags: []int{7}, Extra: wordpress.ExtraFields{"rank_math_title": json.RawMessage(`"SEO"`)}})
+	if _, err := b.Submit(ctx); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+
+	expected := []string{`{"name":"news","wpml_language":"de"}`, `{"rank_math_title":"SEO","tags":[7]}`}
+	if len(body.Requests) != len(expected) {
+		t.Fatalf("Expected %d sub-requests, got %d", len(expected), len(body.Requests))
+	}
+	for i, req := range body.Requests {
+		if string(req.Body) != expected[i] {
+			t.Errorf("Expected body %v, got %s", expected[i], req.Body)
+		}
+	}
+}
+
+func TestDelete_ForceFalseReturnsTrashedEntity(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wp-json/batch/v1" {
+			w.WriteHeader(http.StatusMultiStatus)
+			w.Write([]byte(`{"responses":[{"status":200,"headers":{},"body":{"id":4,"status":"trash"}}]}`))
+			return
+		}
+		w.Write([]byte(`{"id":3,"status":"trash"}`))
+	})
+
+	post, _, err := wp.Posts.Delete(ctx, 3, "force=false")
+	if err != nil || post.ID != 3 || post.Status != "trash" {
+		t.Errorf("Expected trashed post 3, got %+v (%v)", post, err)
+	}
+
+	b := wp.Batch()
+	remove := b.Pages.Delete(4, "force=false")
+	if _, err := b.Submit(ctx); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if remove.Err != nil || remove.Result == nil || remove.Result.Status != "trash" {
+		t.Errorf("Expected trashed page as result, got %+v (%v)", remove.Result, remove.Err)
+	}
+}
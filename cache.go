@@ -0,0 +1,290 @@
+package wordpress
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheMaxEntries is the number of responses kept by a ResponseCache when MaxEntries is zero.
+const DefaultCacheMaxEntries = 1000
+
+// ResponseCache is an in-memory cache of GET responses for Client.Cache.
+//
+// Responses carrying an ETag or Last-Modified header are revalidated with
+// If-None-Match/If-Modified-Since, and a 304 Not Modified is answered from the
+// cache. Responses younger than TTL are served without contacting the server,
+// which also allows caching responses without validators. Requests with a
+// Cache-Control: no-cache header bypass the cache, but refresh it. Successful
+// POST, PUT, PATCH and DELETE requests evict all cached responses of the same
+// route, e.g. updating posts/5 evicts posts/5 and every cached list of posts.
+//
+// It is safe for concurrent use by multiple goroutines.
+type ResponseCache struct {
+	// TTL is how long a response is served without revalidation.
+	TTL time.Duration
+
+	// MaxEntries is the maximum number of cached responses.
+	// Defaults to DefaultCacheMaxEntries.
+	MaxEntries int
+
+	// Identity is added to the keys of all cached responses. Responses are
+	// only served to requests with the same key.
+	//
+	// The keys also include the credentials of the BasicAuthTransport,
+	// JWTAuthTransport, CookieAuthTransport or OAuth1Transport of the client,
+	// and the Authorization and Cookie headers set before the cache, e.g. by
+	// middleware. Clients which share a cache and authenticate in any other
+	// way, e.g. with a custom transport, must use caches with distinct
+	// Identity values to keep their responses apart.
+	Identity string
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	route    string
+	header   http.Header
+	body     []byte
+	storedAt time.Time
+}
+
+// NewResponseCache returns an empty ResponseCache serving responses without revalidation for ttl.
+func NewResponseCache(ttl time.Duration) *ResponseCache {
+	return &ResponseCache{TTL: ttl}
+}
+
+// Len returns the number of cached responses.
+func (rc *ResponseCache) Len() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.entries)
+}
+
+// Purge removes all cached responses.
+func (rc *ResponseCache) Purge() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = nil
+}
+
+// key returns the cache key of req, sent by a client authenticated by
+// transport, the http.RoundTripper of its http.Client.
+func (rc *ResponseCache) key(req *http.Request, transport http.RoundTripper) string {
+	key := rc.Identity + " " + req.URL.String()
+	credentials := req.Header.Get("Authorization") + " " + req.Header.Get("Cookie") + " " + transportIdentity(transport)
+	if strings.TrimSpace(credentials) != "" {
+		sum := sha256.Sum256([]byte(credentials))
+		key += " " + hex.EncodeToString(sum[:])
+	}
+	return key
+}
+
+// cacheIdentifier is implemented by the authenticating transports of this
+// package. cacheIdentity returns a string identifying their credentials.
+type cacheIdentifier interface {
+	cacheIdentity() string
+}
+
+// transportIdentity returns the identities of the authenticating transports
+// in the chain starting at transport.
+func transportIdentity(transport http.RoundTripper) string {
+	var identity []string
+	for i := 0; transport != nil && i < 10; i++ {
+		if identifier, ok := transport.(cacheIdentifier); ok {
+			identity = append(identity, identifier.cacheIdentity())
+		}
+		wrapper, ok := transport.(interface{ transport() http.RoundTripper })
+		if !ok {
+			break
+		}
+		transport = wrapper.transport()
+	}
+	return strings.Join(identity, " ")
+}
+
+func (rc *ResponseCache) get(key string) *cacheEntry {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.entries[key]
+}
+
+func (rc *ResponseCache) put(key string, entry *cacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.entries == nil {
+		rc.entries = make(map[string]*cacheEntry)
+	}
+	max := rc.MaxEntries
+	if max <= 0 {
+		max = DefaultCacheMaxEntries
+	}
+	if _, ok := rc.entries[key]; !ok && len(rc.entries) >= max {
+		var oldestKey string
+		var oldest time.Time
+		for k, e := range rc.entries {
+			if oldestKey == "" || e.storedAt.Before(oldest) {
+				oldestKey, oldest = k, e.storedAt
+			}
+		}
+		delete(rc.entries, oldestKey)
+	}
+	rc.entries[key] = entry
+}
+
+func (rc *ResponseCache) delete(key string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	delete(rc.entries, key)
+}
+
+// invalidate removes all entries of the given route.
+func (rc *ResponseCache) invalidate(route string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for k, e := range rc.entries {
+		if e.route == route {
+			delete(rc.entries, k)
+		}
+	}
+}
+
+func (e *cacheEntry) hasValidators() bool {
+	return e.header.Get("ETag") != "" || e.header.Get("Last-Modified") != ""
+}
+
+// cachedBody is the body of the responses served from a ResponseCache.
+type cachedBody struct {
+	*bytes.Reader
+}
+
+func (cachedBody) Close() error {
+	return nil
+}
+
+// isCachedBody reports whether body is the body of a response served from a
+// ResponseCache, possibly wrapped to count the bytes read.
+func isCachedBody(body io.ReadCloser) bool {
+	if counting, ok := body.(*countingBody); ok {
+		body = counting.ReadCloser
+	}
+	_, ok := body.(cachedBody)
+	return ok
+}
+
+// response builds a 200 response for req from the cached entry.
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	header := e.header.Clone()
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          cachedBody{bytes.NewReader(e.body)},
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// cacheRoute returns the collection a REST API URL belongs to, e.g. "wp/v2/posts"
+// for both /wp-json/wp/v2/posts/5 and /?rest_route=/wp/v2/posts.
+func cacheRoute(u *url.URL) string {
+	segments := strings.Split(restRoute(u), "/")
+	if len(segments) > 3 {
+		segments = segments[:3]
+	}
+	return strings.Join(segments, "/")
+}
+
+// restRoute returns the REST API route of u without leading and trailing
+// slashes, e.g. "wp/v2/posts/5" for both /wp-json/wp/v2/posts/5 and
+// /?rest_route=/wp/v2/posts/5.
+func restRoute(u *url.URL) string {
+	route := u.Query().Get("rest_route")
+	if route == "" {
+		route = u.Path
+		if i := strings.Index(route, "/wp-json/"); i >= 0 {
+			route = route[i+len("/wp-json/"):]
+		}
+	}
+	return strings.Trim(route, "/")
+}
+
+// sendCached sends req through c.Cache, if any.
+func (c *Client) sendCached(ctx context.Context, req *http.Request) (*http.Response, error) {
+	cache := c.Cache
+	if cache == nil {
+		return c.send(ctx, req)
+	}
+
+	if req.Method != "GET" {
+		resp, err := c.send(ctx, req)
+		if err == nil && resp.StatusCode < 300 && req.Method != "HEAD" && req.Method != "OPTIONS" {
+			cache.invalidate(cacheRoute(req.URL))
+		}
+		return resp, err
+	}
+
+	key := cache.key(req, c.client.Transport)
+	var entry *cacheEntry
+	if !strings.Contains(req.Header.Get("Cache-Control"), "no-cache") {
+		// requests with Cache-Control: no-cache always reach the server
+		entry = cache.get(key)
+	}
+	if entry != nil && time.Since(entry.storedAt) < cache.TTL {
+		return entry.response(req), nil
+	}
+	if entry != nil && entry.hasValidators() {
+		req = req.Clone(ctx)
+		if etag := entry.header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		refreshed := &cacheEntry{route: entry.route, header: entry.header, body: entry.body, storedAt: time.Now()}
+		cache.put(key, refreshed)
+		return refreshed.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		if entry != nil {
+			cache.delete(key)
+		}
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	stored := &cacheEntry{route: cacheRoute(req.URL), header: resp.Header.Clone(), body: body, storedAt: time.Now()}
+	if stored.hasValidators() || cache.TTL > 0 {
+		cache.put(key, stored)
+	}
+	return resp, nil
+}
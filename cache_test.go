@@ -0,0 +1,116 @@
+package wordpress_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestCache_RevalidatesWithETag(t *testing.T) {
+	var requests, notModified int32
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-WP-Total", "1")
+		w.Header().Set("X-WP-TotalPages", "1")
+		w.Write([]byte(`[{"id":1,"name":"news"}]`))
+	})
+	wp.Cache = wordpress.NewResponseCache(0)
+
+	for i := 0; i < 3; i++ {
+		tags, resp, err := wp.Tags.List(ctx, nil)
+		if err != nil {
+			t.Fatalf("Should not return error: %v", err)
+		}
+		if len(tags) != 1 || tags[0].Name != "news" {
+			t.Errorf("Expected cached tag, got %v", tags)
+		}
+		if resp.TotalRecords != 1 {
+			t.Errorf("Expected pagination headers to be cached, got %v total records", resp.TotalRecords)
+		}
+		if resp.FromCache != (i > 0) {
+			t.Errorf("Request %v: unexpected FromCache %v", i, resp.FromCache)
+		}
+	}
+	if requests != 3 || notModified != 2 {
+		t.Errorf("Expected 3 requests of which 2 conditional, got %v and %v", requests, notModified)
+	}
+}
+
+func TestCache_TTLAndInvalidation(t *testing.T) {
+	var requests int32
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"id":5,"slug":"hello"}`))
+	})
+	wp.Cache = wordpress.NewResponseCache(time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := wp.Posts.Get(ctx, 5, nil); err != nil {
+			t.Fatalf("Should not return error: %v", err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected fresh response to be served from cache, got %v requests", requests)
+	}
+
+	// other routes are not affected by a write to posts
+	wp.Settings.List(ctx)
+	if _, _, err := wp.Posts.Update(ctx, 5, &wordpress.Post{Slug: "hello"}); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if wp.Cache.Len() != 1 {
+		t.Errorf("Expected only the settings response to remain cached, got %v entries", wp.Cache.Len())
+	}
+
+	if _, resp, _ := wp.Posts.Get(ctx, 5, nil); resp.FromCache {
+		t.Errorf("Post should be fetched again after update")
+	}
+	if requests != 4 {
+		t.Errorf("Expected 4 requests, got %v", requests)
+	}
+}
+
+func TestCache_KeyedByTransportCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		w.Header().Set("X-From-Cache", "1") // set by some proxies; must not count as cached
+		fmt.Fprintf(w, `{"id":1,"name":%q}`, user)
+	}))
+	defer server.Close()
+
+	cache := wordpress.NewResponseCache(time.Minute)
+	newClient := func(user string) *wordpress.Client {
+		transport := &wordpress.BasicAuthTransport{Username: user, Password: "secret", Transport: server.Client().Transport}
+		client, err := wordpress.NewClient(server.URL, transport.Client())
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		client.Cache = cache
+		return client
+	}
+	alice, bob := newClient("alice"), newClient("bob")
+	ctx := context.Background()
+
+	me, resp, err := alice.Users.Me(ctx, nil)
+	if err != nil || me.Name != "alice" || resp.FromCache {
+		t.Fatalf("Unexpected first response %+v (%v), from cache %v", me, err, resp.FromCache)
+	}
+	if me, resp, _ = bob.Users.Me(ctx, nil); me.Name != "bob" || resp.FromCache {
+		t.Errorf("Expected bob not to get alice's cached response, got %v", me.Name)
+	}
+	if me, resp, _ = alice.Users.Me(ctx, nil); me.Name != "alice" || !resp.FromCache {
+		t.Errorf("Expected alice's response from the cache, got %v (from cache %v)", me.Name, resp.FromCache)
+	}
+}
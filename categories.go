@@ -3,6 +3,7 @@ package wordpress
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // Category represents a WordPress post/page category.
@@ -15,6 +16,10 @@ type Category struct {
 	Slug        string `json:"slug"`
 	Taxonomy    string `json:"taxonomy"`
 	Parent      int    `json:"parent"`
+	Meta        Meta   `json:"meta,omitzero"` // Meta fields registered with show_in_rest.
+
+	// Extra holds the fields without a struct field, e.g. added by plugins.
+	Extra ExtraFields `json:"-"`
 }
 
 // CategoriesService provides access to the category related functions in the WordPress REST API.
@@ -40,6 +45,18 @@ func (c *CategoriesService) List(ctx context.Context, opts *CategoryListOptions)
 	return categories, resp, nil
 }
 
+// All returns an iterator over all categories matching opts, fetching one page after another
+// as the iteration advances.
+func (c *CategoriesService) All(ctx context.Context, opts *CategoryListOptions) iter.Seq2[*Category, error] {
+	return allPages(ctx, opts, c.List)
+}
+
+// ListParallel returns all categories matching opts. The remaining pages are fetched concurrently
+// once the first page has reported the total number of pages.
+func (c *CategoriesService) ListParallel(ctx context.Context, opts *CategoryListOptions, popts *ParallelOptions) ([]*Category, *Response, error) {
+	return listParallel(ctx, opts, c.List, popts)
+}
+
 // Create creates a new category.
 func (c *CategoriesService) Create(ctx context.Context, newCategory *Category) (*Category, *Response, error) {
 	var created Category
@@ -45,13 +45,14 @@ type Error struct {
 	Response *http.Response // HTTP response that caused this error
 	Code     string         `json:"code"`
 	Message  string         `json:"message"`
-	Data     struct {
-		Status int               `json:"status"`
-		Params map[string]string `json:"params"`
-	} `json:"data"`
+	Data     ErrorData      `json:"data"`
 }
 
 func (e *Error) Error() string {
+	if e.Response == nil || e.Response.Request == nil {
+		// errors embedded in a response have no response of their own
+		return fmt.Sprintf("%v: %v", e.Code, e.Message)
+	}
 	return fmt.Sprintf("%v %v: %d %v",
 		e.Response.Request.Method, sanitizeURL(e.Response.Request.URL),
 		e.Response.StatusCode, e.Message)
@@ -72,6 +73,22 @@ type Client struct {
 	// if ProcessRawResponseBody is set to true, response from WordPress will be decoded into RawBody filed of response struct
 	ProcessRawResponseBody bool
 
+	// RetryPolicy configures automatic retries of failed requests. Retries are disabled if nil.
+	RetryPolicy *RetryPolicy
+
+	// Limiter throttles every request sent by the client, across all services. Requests are not throttled if nil.
+	Limiter Limiter
+
+	// Cache stores GET responses and revalidates them with conditional requests. Responses are not cached if nil.
+	Cache *ResponseCache
+
+	// SendExtraFields adds the ExtraFields of entities to the requests creating or
+	// updating them, including the create and update operations of a Batch.
+	SendExtraFields bool
+
+	// Observer is notified at the start and end of every request, e.g. to record metrics. No notifications are sent if nil.
+	Observer Observer
+
 	Categories *CategoriesService
 	Comments   *CommentsService
 	Media      *MediaService
@@ -85,8 +102,9 @@ type Client struct {
 	Types      *TypesService
 	Users      *UsersService
 
-	client  *http.Client
-	baseURL *url.URL
+	client      *http.Client
+	baseURL     *url.URL
+	middlewares []Middleware
 
 	common Service // Reuse a single struct instead of allocating one for each service on the heap.
 }
@@ -107,6 +125,22 @@ type ListOptions struct {
 	Page    int    `url:"page,omitempty"`             // Current page of the collection.
 	PerPage int    `url:"per_page,omitempty"`         // Maximum number of items to be returned in result set.
 	Search  string `url:"search,omitempty"`           // Limit results to those matching a string.
+	Embed   Embed  `url:"_embed,omitempty"`           // Linked resources to embed in the response.
+
+	// Fields limits the response to the given fields, e.g. "id", "title.rendered",
+	// "meta.foo" or "_links". The fields missing from a response are left at
+	// their zero value, which is not sent when the entity is updated.
+	Fields []string `url:"_fields,omitempty,comma"`
+}
+
+// GetOptions specifies the optional parameters to various Get methods.
+type GetOptions struct {
+	Context  string `url:"context,omitempty"`  // Scope under which the request is made; determines fields present in response.
+	Password string `url:"password,omitempty"` // The password for the entity if it is password protected.
+	Embed    Embed  `url:"_embed,omitempty"`   // Linked resources to embed in the response.
+
+	// Fields limits the response to the given fields, see ListOptions.Fields.
+	Fields []string `url:"_fields,omitempty,comma"`
 }
 
 // Response is a WordPress REST API response. This wraps the standard http.Response
@@ -118,6 +152,9 @@ type Response struct {
 	// This value is only used when ProcessRawResponseBody flag of client is turned on
 	RawBody interface{}
 
+	// FromCache is true if the response was served from the client Cache.
+	FromCache bool
+
 	// These fields provide the page values for paginating through a set of
 	// results. Any or all of these may be set to the zero value for
 	// responses that are not part of a paginated set, or for which there
@@ -140,6 +177,7 @@ type DeleteResponse struct {
 // r must not be nil.
 func newResponse(r *http.Response) *Response {
 	response := &Response{Response: r}
+	response.FromCache = r.Body != nil && isCachedBody(r.Body)
 	response.populatePageValues()
 	return response
 }
@@ -174,6 +212,8 @@ func (r *Response) populatePageValues() {
 }
 
 // NewClient returns an initalized Client for the given baseURL and httpClient.
+// baseURL is the root of the WordPress site, which may be a subdirectory,
+// e.g. https://example.com/blog.
 func NewClient(baseURLStr string, httpClient *http.Client) (*Client, error) {
 	if strings.Contains(baseURLStr, apiPathPrefix) {
 		return nil, ErrURLContainsWPV2
@@ -217,20 +257,24 @@ func NewClient(baseURLStr string, httpClient *http.Client) (*Client, error) {
 }
 
 func (c *Client) getRequestURL(s string) (*url.URL, error) {
-	var apiPath string
-	if c.NonPrettyPermalinks {
-		apiPath = "/?rest_route="
-	} else {
-		apiPath = "/wp-json"
+	if s == "" {
+		return c.getRouteURL("/")
 	}
+	return c.getRouteURL(fmt.Sprintf("%s%s", apiPathPrefix, "/"+s))
+}
 
-	if s == "" {
-		apiPath += "/"
+// getRouteURL returns the URL of a REST API route outside of the wp/v2
+// namespace, e.g. "/batch/v1".
+func (c *Client) getRouteURL(route string) (*url.URL, error) {
+	// relative to the base URL, so that sites installed in a subdirectory work
+	var apiPath string
+	if c.NonPrettyPermalinks {
+		apiPath = "?rest_route="
 	} else {
-		apiPath = fmt.Sprintf("%s%s%s", apiPath, apiPathPrefix, "/"+s)
+		apiPath = "wp-json"
 	}
 
-	return c.baseURL.Parse(apiPath)
+	return c.baseURL.Parse(apiPath + route)
 }
 
 // GetCommonService returns a reusable single instance of Service to allocate it to custom services.
@@ -248,21 +292,31 @@ func (c *Client) AddOptions(s string, opt interface{}) (string, error) {
 		connector = "?"
 	}
 
+	qs, err := encodeOptions(opt)
+	if err != nil || qs == "" {
+		return s, err
+	}
+
+	return fmt.Sprintf("%s%s%s", s, connector, qs), nil
+}
+
+// encodeOptions encodes opt as a query string. opt may be nil, a string
+// holding an encoded query, or a struct whose fields may contain "url" tags.
+func encodeOptions(opt interface{}) (string, error) {
 	v := reflect.ValueOf(opt)
 	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
-		return s, nil
+		return "", nil
 	}
 
 	if v.Kind() == reflect.String {
-		return fmt.Sprintf("%s%s%s", s, connector, opt.(string)), nil
+		return opt.(string), nil
 	}
 
 	qs, err := query.Values(opt)
 	if err != nil {
-		return s, err
+		return "", err
 	}
-
-	return fmt.Sprintf("%s%s%s", s, connector, qs.Encode()), nil
+	return qs.Encode(), nil
 }
 
 // NewRequest creates an API request. A relative URL can be provided in urlStr,
@@ -275,15 +329,27 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	if err != nil {
 		return nil, err
 	}
+	return c.newRequest(method, u, body)
+}
 
+// newRequest creates an API request for u, JSON encoding body if it is not nil.
+func (c *Client) newRequest(method string, u *url.URL, body interface{}) (*http.Request, error) {
 	var buf io.ReadWriter
 	if body != nil {
-		buf = new(bytes.Buffer)
-		enc := json.NewEncoder(buf)
+		encoded := new(bytes.Buffer)
+		enc := json.NewEncoder(encoded)
 		enc.SetEscapeHTML(false)
 		if encErr := enc.Encode(body); encErr != nil {
 			return nil, encErr
 		}
+		if c.SendExtraFields {
+			data, mergeErr := mergeExtra(encoded.Bytes(), body)
+			if mergeErr != nil {
+				return nil, mergeErr
+			}
+			encoded = bytes.NewBuffer(data)
+		}
+		buf = encoded
 	}
 
 	req, err := http.NewRequest(method, u.String(), buf)
@@ -308,10 +374,27 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 //
 // The provided ctx must be non-nil. If it is canceled or times out,
 // ctx.Err() will be returned.
+//
+// If the client has a RetryPolicy, failed requests are retried before Do returns.
+// If the client has a Cache, GET responses may be served from it.
+// Middlewares registered with Use are run around every call, and the client
+// Observer, if any, is notified before and after.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	handler := Handler(c.do)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handler = c.middlewares[i](handler)
+	}
+	if c.Observer != nil {
+		return c.observe(ctx, req, v, handler)
+	}
+	return handler(ctx, req, v)
+}
+
+// do is the innermost Handler: it sends req and decodes the response into v.
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
 	req = req.WithContext(ctx)
 
-	resp, err := c.client.Do(req)
+	resp, err := c.sendCached(ctx, req)
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
@@ -332,6 +415,10 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 		return nil, err
 	}
 
+	if trace := traceFromContext(ctx); trace != nil {
+		resp.Body = &countingBody{ReadCloser: resp.Body, n: &trace.bytes}
+	}
+
 	// nolint: errcheck
 	defer func() {
 		// Drain up to 512 bytes and close the body to let the Transport reuse the connection
@@ -360,6 +447,9 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 			}
 
 			err = json.Unmarshal(body, v)
+			if err == nil {
+				fillExtra(body, v)
+			}
 
 			// if ProcessRawResponseBody is turned on, decode body of WordPress response and assign to this function's response
 			if c.ProcessRawResponseBody {
@@ -478,7 +568,7 @@ func (c *Client) Delete(ctx context.Context, url string, params interface{}, res
 
 	req.Header.Set("HTTP_X_HTTP_METHOD_OVERRIDE", "DELETE")
 
-	if req.URL.Query().Get("force") != "" {
+	if isForced(req.URL.Query()) {
 		var deleteResp DeleteResponse
 
 		resp, err := c.Do(ctx, req, &deleteResp)
@@ -490,6 +580,7 @@ func (c *Client) Delete(ctx context.Context, url string, params interface{}, res
 			if err := json.Unmarshal(deleteResp.Previous, &result); err != nil {
 				return resp, err
 			}
+			fillExtra(deleteResp.Previous, result)
 		}
 
 		return resp, nil
@@ -498,6 +589,14 @@ func (c *Client) Delete(ctx context.Context, url string, params interface{}, res
 	return c.Do(ctx, req, &result)
 }
 
+// isForced reports whether the query of a DELETE request deletes permanently
+// with force=true, in which case WordPress returns the deleted entity as
+// previous instead of the trashed entity.
+func isForced(values url.Values) bool {
+	force, _ := strconv.ParseBool(values.Get("force"))
+	return force
+}
+
 // PostData allows uploading of binary objects to the WordPress REST API.
 func (c *Client) PostData(ctx context.Context, urlStr string, content []byte, contentType string, filename string, result interface{}) (*Response, error) {
 
@@ -521,6 +620,8 @@ func (c *Client) PostData(ctx context.Context, urlStr string, content []byte, co
 		return nil, err
 	}
 
+	// http.NewRequest snapshots the buffer into req.GetBody, so the multipart
+	// body can be rebuilt when the request is retried
 	req, err := http.NewRequest("POST", u.String(), &buf)
 	if err != nil {
 		return nil, err
@@ -555,17 +656,17 @@ func sanitizeURL(uri *url.URL) *url.URL {
 // present. A response is considered an error if it has a status code outside
 // the 200 range or equal to 202 Accepted.
 // API error responses are expected to have either no response
-// body, or a JSON response body that maps to ErrorResponse. Any other
-// response body will be silently ignored.
+// body, or a JSON response body that maps to Error. Any other
+// response body is returned as an UnexpectedResponseError.
 func checkResponse(r *http.Response) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
 		return nil
 	}
 	errorResponse := &Error{Response: r}
 	data, err := ioutil.ReadAll(r.Body)
-	if err == nil && data != nil {
+	if err == nil && len(bytes.TrimSpace(data)) > 0 {
 		if jsonErr := json.Unmarshal(data, errorResponse); jsonErr != nil {
-			return jsonErr
+			return newUnexpectedResponseError(r, data)
 		}
 	}
 	return errorResponse
@@ -3,6 +3,8 @@ package wordpress_test
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -57,3 +59,16 @@ func initTestClient() (*wordpress.Client, context.Context) {
 
 	return client, context.Background()
 }
+
+// initStubClient creates a wordpress client talking to a local stub server
+// serving handler. The server is closed when the test finishes.
+func initStubClient(t *testing.T, handler http.HandlerFunc) (*wordpress.Client, context.Context) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := wordpress.NewClient(server.URL, server.Client())
+	if err != nil {
+		t.Fatalf("Failed to create stub client: %v", err)
+	}
+	return client, context.Background()
+}
@@ -3,28 +3,77 @@ package wordpress
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // Comment represents a WordPress post comment.
 type Comment struct {
+	collection *CommentsService
+	tracker
+
 	ID              int            `json:"id,omitempty"`
 	AvatarURL       string         `json:"avatar_url,omitempty"`
-	AvatarURLs      AvatarURLS     `json:"avatar_urls,omitempty"`
+	AvatarURLs      AvatarURLS     `json:"avatar_urls,omitempty,omitzero"`
 	Author          int            `json:"author,omitempty"`
 	AuthorEmail     string         `json:"author_email,omitempty"`
 	AuthorIP        string         `json:"author_ip,omitempty"`
 	AuthorName      string         `json:"author_name,omitempty"`
 	AuthorURL       string         `json:"author_url,omitempty"`
 	AuthorUserAgent string         `json:"author_user_agent,omitempty"`
-	Content         RenderedString `json:"content,omitempty"`
-	Date            Time           `json:"date,omitempty"`
-	DateGMT         Time           `json:"date_gmt,omitempty"`
+	Content         RenderedString `json:"content,omitempty,omitzero"`
+	Date            Time           `json:"date,omitempty,omitzero"`
+	DateGMT         Time           `json:"date_gmt,omitempty,omitzero"`
 	Karma           int            `json:"karma,omitempty"`
 	Link            string         `json:"link,omitempty"`
 	Parent          int            `json:"parent,omitempty"`
 	Post            int            `json:"post,omitempty"`
 	Status          string         `json:"status,omitempty"`
 	Type            string         `json:"type,omitempty"`
+	Meta            Meta           `json:"meta,omitzero"` // Meta fields registered with show_in_rest.
+
+	Embedded *Embedded `json:"-"` // Decoded from _embedded when requested with Embed, never sent.
+	Links    Links     `json:"-"` // Decoded from _links, never sent.
+
+	// Extra holds the fields without a struct field, e.g. added by plugins.
+	Extra ExtraFields `json:"-"`
+}
+
+func (entity *Comment) setService(c *CommentsService) {
+	entity.collection = c
+}
+
+func (entity *Comment) service() entityService[Comment] {
+	if entity.collection == nil {
+		return nil
+	}
+	return entity.collection
+}
+
+// IsDirty reports whether the comment has changed since it was fetched.
+func (entity *Comment) IsDirty() bool {
+	return len(entityChanges(entity)) > 0
+}
+
+// Changed returns the sorted JSON names of the fields changed since the comment was fetched.
+func (entity *Comment) Changed() []string {
+	return changedFields(entityChanges(entity))
+}
+
+// Save updates the comment with the fields changed since it was fetched, and
+// refreshes it from the response. It sends no request if nothing has changed.
+func (entity *Comment) Save(ctx context.Context) (*Response, error) {
+	return saveEntity(ctx, entity, entity.ID)
+}
+
+// Delete moves the comment to the trash, or deletes it permanently if force is
+// set, and refreshes it from the response.
+func (entity *Comment) Delete(ctx context.Context, force bool) (*Response, error) {
+	return deleteEntity(ctx, entity, entity.ID, deleteParams(force))
+}
+
+// Reload fetches the comment again, discarding unsaved changes.
+func (entity *Comment) Reload(ctx context.Context) (*Response, error) {
+	return reloadEntity(ctx, entity, entity.ID)
 }
 
 // CommentsService provides access to the comment related functions in the WordPress REST API.
@@ -47,13 +96,35 @@ func (c *CommentsService) List(ctx context.Context, opts *CommentListOptions) ([
 	if err != nil {
 		return nil, resp, err
 	}
+
+	// set collection object to save, delete and reload the entity
+	for _, entity := range comments {
+		entity.setService(c)
+	}
+
 	return comments, resp, nil
 }
 
+// All returns an iterator over all comments matching opts, fetching one page after another
+// as the iteration advances.
+func (c *CommentsService) All(ctx context.Context, opts *CommentListOptions) iter.Seq2[*Comment, error] {
+	return allPages(ctx, opts, c.List)
+}
+
+// ListParallel returns all comments matching opts. The remaining pages are fetched concurrently
+// once the first page has reported the total number of pages.
+func (c *CommentsService) ListParallel(ctx context.Context, opts *CommentListOptions, popts *ParallelOptions) ([]*Comment, *Response, error) {
+	return listParallel(ctx, opts, c.List, popts)
+}
+
 // Create creates a new comment.
 func (c *CommentsService) Create(ctx context.Context, newComment *Comment) (*Comment, *Response, error) {
 	var created Comment
 	resp, err := c.Client.Create(ctx, "comments", newComment, &created)
+
+	// set collection object to save, delete and reload the entity
+	created.setService(c)
+
 	return &created, resp, err
 }
 
@@ -62,14 +133,39 @@ func (c *CommentsService) Get(ctx context.Context, id int, params interface{}) (
 	var entity Comment
 	entityURL := fmt.Sprintf("comments/%v", id)
 	resp, err := c.Client.Get(ctx, entityURL, params, &entity)
+
+	// set collection object to save, delete and reload the entity
+	entity.setService(c)
+
 	return &entity, resp, err
 }
 
 // Update updates a single comment with the given id.
 func (c *CommentsService) Update(ctx context.Context, id int, post *Comment) (*Comment, *Response, error) {
+	return c.update(ctx, id, post)
+}
+
+// update updates the comment with the given id with body, e.g. a Comment or only its changed fields.
+func (c *CommentsService) update(ctx context.Context, id int, body interface{}) (*Comment, *Response, error) {
 	var updated Comment
 	entityURL := fmt.Sprintf("comments/%v", id)
-	resp, err := c.Client.Update(ctx, entityURL, post, &updated)
+	resp, err := c.Client.Update(ctx, entityURL, body, &updated)
+
+	// set collection object to save, delete and reload the entity
+	updated.setService(c)
+
+	return &updated, resp, err
+}
+
+// Patch changes only the fields of the comment with the given id which are set in patch.
+func (c *CommentsService) Patch(ctx context.Context, id int, patch CommentPatch) (*Comment, *Response, error) {
+	var updated Comment
+	entityURL := fmt.Sprintf("comments/%v", id)
+	resp, err := c.Client.Patch(ctx, entityURL, patch, &updated)
+
+	// set collection object to save, delete and reload the entity
+	updated.setService(c)
+
 	return &updated, resp, err
 }
 
@@ -78,5 +174,9 @@ func (c *CommentsService) Delete(ctx context.Context, id int, params interface{}
 	var deleted Comment
 	entityURL := fmt.Sprintf("comments/%v", id)
 	resp, err := c.Client.Delete(ctx, entityURL, params, &deleted)
+
+	// set collection object to save, delete and reload the entity
+	deleted.setService(c)
+
 	return &deleted, resp, err
 }
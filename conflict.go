@@ -0,0 +1,238 @@
+package wordpress
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// DefaultConflictRetries is the number of merged updates attempted after
+// conflicts if ConflictOptions.MaxRetries is 0.
+const DefaultConflictRetries = 3
+
+// ConflictError is returned from the UpdateIfUnmodified methods if the entity
+// has been modified on the server since the version the update is based on.
+// It matches ErrConflict with errors.Is; use errors.As to inspect it, e.g.
+//
+//	var conflict *wordpress.ConflictError[wordpress.Post]
+//	if errors.As(err, &conflict) {
+//		log.Printf("%q was edited at %v", conflict.Server.Title.Raw, conflict.Actual)
+//	}
+type ConflictError[T any] struct {
+	Server    *T        // The current version on the server, fetched with the edit context.
+	Attempted *T        // The entity whose update was rejected.
+	Expected  time.Time // The modified_gmt the update was based on.
+	Actual    time.Time // The modified_gmt of the server version.
+}
+
+func (e *ConflictError[T]) Error() string {
+	return fmt.Sprintf("%v: modified at %v, expected %v",
+		ErrConflict, e.Actual.Format(time.RFC3339), e.Expected.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrConflict.
+func (e *ConflictError[T]) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// ConflictOptions configure the UpdateIfUnmodified methods.
+type ConflictOptions[T any] struct {
+	// Merge is called on a conflict to combine the attempted change with the
+	// server version, e.g. when both changed different fields. The entity it
+	// returns is written instead, based on the server version. Return an
+	// error, such as the conflict itself, to give up. Conflicts are returned
+	// as is if Merge is nil.
+	Merge func(conflict *ConflictError[T]) (*T, error)
+
+	// MaxRetries limits the merged updates attempted, DefaultConflictRetries if 0.
+	MaxRetries int
+}
+
+// MergeChanges is a ConflictOptions.Merge function for posts and pages
+// fetched from their service. It applies the fields changed in the attempted
+// entity since it was fetched to the server version, unless the server
+// version has changed one of them as well, in which case it returns the conflict.
+func MergeChanges[T any](conflict *ConflictError[T]) (*T, error) {
+	attempted, ok := any(conflict.Attempted).(interface{ base() snapshot })
+	if !ok || attempted.base() == nil {
+		return nil, conflict
+	}
+	base := attempted.base()
+	changes := base.changes(conflict.Attempted)
+	server := takeSnapshot(conflict.Server)
+
+	var overlapping []string
+	for _, name := range changedFields(changes) {
+		if !sameValue(base[name], server[name]) {
+			overlapping = append(overlapping, name)
+		}
+	}
+	if len(overlapping) > 0 {
+		return nil, fmt.Errorf("%w, both changed %v", conflict, overlapping)
+	}
+
+	merged := *conflict.Server
+	from := reflect.ValueOf(conflict.Attempted).Elem()
+	to := reflect.ValueOf(&merged).Elem()
+	var extra ExtraFields
+	if field := extraField(to); field.IsValid() {
+		extra = make(ExtraFields)
+		for name, raw := range field.Interface().(ExtraFields) {
+			extra[name] = raw
+		}
+		defer field.Set(reflect.ValueOf(extra))
+	}
+	for name, raw := range changes {
+		if field, ok := jsonField(to, name); ok {
+			value, _ := jsonField(from, name)
+			field.Set(value)
+		} else if extra != nil {
+			extra[name] = raw
+		}
+	}
+	return &merged, nil
+}
+
+// sameValue reports whether the server value of a field still equals its
+// base value. Objects are only compared by the keys of the base value, since
+// the base may have been fetched in the view context, e.g. with only the
+// rendered title, while the server version is fetched in the edit context.
+func sameValue(base, server json.RawMessage) bool {
+	if bytes.Equal(base, server) {
+		return true
+	}
+	var baseObject, serverObject map[string]json.RawMessage
+	if json.Unmarshal(base, &baseObject) != nil || json.Unmarshal(server, &serverObject) != nil ||
+		baseObject == nil || serverObject == nil {
+		return false
+	}
+	for key, value := range baseObject {
+		if !bytes.Equal(value, serverObject[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// getUncached fetches the entity at entityURL like Client.Get, but bypasses
+// Client.Cache, so that versions are always checked against the server.
+func getUncached(ctx context.Context, client *Client, entityURL string, params interface{}, result interface{}) (*Response, error) {
+	u, err := client.AddOptions(entityURL, params)
+	if err != nil {
+		return nil, err
+	}
+	req, err := client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Cache-Control", "no-cache")
+	return client.Do(ctx, req, result)
+}
+
+// updateIfUnmodified updates the entity at entityURL if its modified_gmt on
+// the server is still the one of entity. fetched is called on every entity
+// decoded from a response.
+func updateIfUnmodified[T any](ctx context.Context, client *Client, entityURL string, entity *T, opts *ConflictOptions[T],
+	modifiedGMT func(*T) time.Time, fetched func(*T)) (*T, *Response, error) {
+	expected := modifiedGMT(entity)
+	if expected.IsZero() {
+		return nil, nil, fmt.Errorf("cannot update %v without the modified_gmt it is based on", entityURL)
+	}
+	maxRetries := DefaultConflictRetries
+	if opts != nil && opts.MaxRetries > 0 {
+		maxRetries = opts.MaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		updated, resp, err := updateOnce(ctx, client, entityURL, entity, expected, modifiedGMT, fetched)
+		var conflict *ConflictError[T]
+		if !errors.As(err, &conflict) || opts == nil || opts.Merge == nil || attempt >= maxRetries {
+			return updated, resp, err
+		}
+		merged, mergeErr := opts.Merge(conflict)
+		if mergeErr != nil {
+			return nil, resp, mergeErr
+		}
+		entity, expected = merged, conflict.Actual
+	}
+}
+
+// updateOnce checks the modified_gmt of the entity at entityURL, bypassing
+// Client.Cache, and updates it. The update is also sent with
+// If-Unmodified-Since, so that servers which support it reject changes made
+// between the check and the update.
+func updateOnce[T any](ctx context.Context, client *Client, entityURL string, entity *T, expected time.Time,
+	modifiedGMT func(*T) time.Time, fetched func(*T)) (*T, *Response, error) {
+	var current struct {
+		ModifiedGMT TimeGMT `json:"modified_gmt"`
+	}
+	resp, err := getUncached(ctx, client, entityURL, &GetOptions{Fields: []string{"modified_gmt"}}, &current)
+	if err != nil {
+		return nil, resp, err
+	}
+	if !current.ModifiedGMT.Equal(expected) {
+		return conflictError(ctx, client, entityURL, entity, expected, modifiedGMT, fetched)
+	}
+
+	req, err := client.NewRequest("PUT", entityURL, entity)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("HTTP_X_HTTP_METHOD_OVERRIDE", "PUT")
+	req.Header.Set("If-Unmodified-Since", expected.UTC().Format(http.TimeFormat))
+
+	var updated T
+	resp, err = client.Do(ctx, req, &updated)
+	if resp != nil && resp.StatusCode == http.StatusPreconditionFailed {
+		return conflictError(ctx, client, entityURL, entity, expected, modifiedGMT, fetched)
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+	fetched(&updated)
+	return &updated, resp, nil
+}
+
+// conflictError fetches the server version of the entity at entityURL and returns the conflict with attempted.
+func conflictError[T any](ctx context.Context, client *Client, entityURL string, attempted *T, expected time.Time,
+	modifiedGMT func(*T) time.Time, fetched func(*T)) (*T, *Response, error) {
+	var server T
+	resp, err := getUncached(ctx, client, entityURL, &GetOptions{Context: "edit"}, &server)
+	if err != nil {
+		return nil, resp, err
+	}
+	fetched(&server)
+	return nil, resp, &ConflictError[T]{
+		Server:    &server,
+		Attempted: attempted,
+		Expected:  expected,
+		Actual:    modifiedGMT(&server),
+	}
+}
+
+// UpdateIfUnmodified updates the post with the given id like Update, unless it
+// has been modified on the server since post.ModifiedGMT, e.g. by another
+// editor. It then returns a *ConflictError[Post], or retries with the post
+// returned by opts.Merge. opts may be nil.
+func (c *PostsService) UpdateIfUnmodified(ctx context.Context, id int, post *Post, opts *ConflictOptions[Post]) (*Post, *Response, error) {
+	entityURL := fmt.Sprintf("posts/%v", id)
+	return updateIfUnmodified(ctx, c.Client, entityURL, post, opts,
+		func(p *Post) time.Time { return p.ModifiedGMT.Time },
+		func(p *Post) { p.setService(c) })
+}
+
+// UpdateIfUnmodified updates the page with the given id like Update, unless it
+// has been modified on the server since page.ModifiedGMT, e.g. by another
+// editor. It then returns a *ConflictError[Page], or retries with the page
+// returned by opts.Merge. opts may be nil.
+func (c *PagesService) UpdateIfUnmodified(ctx context.Context, id int, page *Page, opts *ConflictOptions[Page]) (*Page, *Response, error) {
+	entityURL := fmt.Sprintf("pages/%v", id)
+	return updateIfUnmodified(ctx, c.Client, entityURL, page, opts,
+		func(p *Page) time.Time { return p.ModifiedGMT.Time },
+		func(p *Page) { p.setService(c) })
+}
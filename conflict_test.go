@@ -0,0 +1,216 @@
+package wordpress_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+// conflictServer serves a single post, like WordPress with the raw title and
+// excerpt only in the edit context.
+type conflictServer struct {
+	mu       sync.Mutex
+	title    string
+	excerpt  string
+	modified string
+	puts     []map[string]json.RawMessage
+	preconds []string
+
+	// rejectPut is called instead of the next update, which fails with 412 Precondition Failed.
+	rejectPut func(s *conflictServer)
+}
+
+func newConflictServer() *conflictServer {
+	return &conflictServer{title: "Title", excerpt: "Excerpt", modified: "2024-05-01T10:00:00"}
+}
+
+// edit changes the post like another editor would.
+func (s *conflictServer) edit(title, excerpt string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.title, s.excerpt = title, excerpt
+	s.modified = "2024-05-01T11:00:00"
+}
+
+func (s *conflictServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	edit := r.URL.Query().Get("context") == "edit"
+	switch r.Method {
+	case "GET":
+		if r.URL.Query().Get("_fields") == "modified_gmt" {
+			fmt.Fprintf(w, `{"modified_gmt":%q}`, s.modified)
+			return
+		}
+	case "PUT":
+		edit = true
+		s.preconds = append(s.preconds, r.Header.Get("If-Unmodified-Since"))
+		if reject := s.rejectPut; reject != nil {
+			s.rejectPut = nil
+			reject(s)
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`{"code":"precondition_failed","message":"modified","data":{"status":412}}`))
+			return
+		}
+		var body map[string]json.RawMessage
+		json.NewDecoder(r.Body).Decode(&body)
+		s.puts = append(s.puts, body)
+		for name, field := range map[string]*string{"title": &s.title, "excerpt": &s.excerpt} {
+			var value struct{ Raw *string }
+			if json.Unmarshal(body[name], &value) == nil && value.Raw != nil {
+				*field = *value.Raw
+			}
+		}
+		s.modified = "2024-05-01T12:00:00"
+	}
+	rendered := func(text string) string {
+		if edit {
+			return fmt.Sprintf(`{"raw":%q,"rendered":%q}`, text, "<p>"+text+"</p>")
+		}
+		return fmt.Sprintf(`{"rendered":%q}`, "<p>"+text+"</p>")
+	}
+	fmt.Fprintf(w, `{"id":1,"title":%v,"excerpt":%v,"modified_gmt":%q}`, rendered(s.title), rendered(s.excerpt), s.modified)
+}
+
+func TestPostsService_UpdateIfUnmodified(t *testing.T) {
+	server := newConflictServer()
+	wp, ctx := initStubClient(t, server.ServeHTTP)
+
+	post, _, err := wp.Posts.Get(ctx, 1, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	post.Title.Raw = "New title"
+	updated, _, err := wp.Posts.UpdateIfUnmodified(ctx, 1, post, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if updated.Title.Raw != "New title" || updated.IsDirty() {
+		t.Errorf("Unexpected updated post %+v", updated)
+	}
+	if len(server.preconds) != 1 || server.preconds[0] != "Wed, 01 May 2024 10:00:00 GMT" {
+		t.Errorf("Unexpected If-Unmodified-Since %v", server.preconds)
+	}
+
+	if _, _, err := wp.Posts.UpdateIfUnmodified(ctx, 1, &wordpress.Post{}, nil); err == nil {
+		t.Errorf("Expected error for a post without modified_gmt")
+	}
+}
+
+func TestPostsService_UpdateIfUnmodifiedConflict(t *testing.T) {
+	server := newConflictServer()
+	wp, ctx := initStubClient(t, server.ServeHTTP)
+
+	post, _, _ := wp.Posts.Get(ctx, 1, nil)
+	server.edit("Their title", "Excerpt")
+	post.Title.Raw = "My title"
+	_, _, err := wp.Posts.UpdateIfUnmodified(ctx, 1, post, nil)
+	if !errors.Is(err, wordpress.ErrConflict) {
+		t.Fatalf("Expected ErrConflict, got %v", err)
+	}
+	var conflict *wordpress.ConflictError[wordpress.Post]
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Expected *ConflictError[Post], got %T", err)
+	}
+	if conflict.Server.Title.Raw != "Their title" || conflict.Attempted.Title.Raw != "My title" {
+		t.Errorf("Unexpected conflict %+v", conflict)
+	}
+	if conflict.Actual.Hour() != 11 || conflict.Expected.Hour() != 10 {
+		t.Errorf("Unexpected versions %v, %v", conflict.Actual, conflict.Expected)
+	}
+	if len(server.puts) != 0 {
+		t.Errorf("Expected no update on conflict, got %v", server.puts)
+	}
+
+	// overlapping changes cannot be merged
+	_, _, err = wp.Posts.UpdateIfUnmodified(ctx, 1, post, &wordpress.ConflictOptions[wordpress.Post]{
+		Merge: wordpress.MergeChanges[wordpress.Post],
+	})
+	if !errors.Is(err, wordpress.ErrConflict) || !strings.Contains(err.Error(), "[title]") {
+		t.Errorf("Expected conflict on title, got %v", err)
+	}
+}
+
+func TestPostsService_UpdateIfUnmodifiedMerge(t *testing.T) {
+	server := newConflictServer()
+	wp, ctx := initStubClient(t, server.ServeHTTP)
+
+	// fetched in the view context, without the raw title
+	post, _, _ := wp.Posts.Get(ctx, 1, nil)
+	server.edit("Title", "Their excerpt")
+	post.Title.Raw = "My title"
+	updated, _, err := wp.Posts.UpdateIfUnmodified(ctx, 1, post, &wordpress.ConflictOptions[wordpress.Post]{
+		Merge: wordpress.MergeChanges[wordpress.Post],
+	})
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if updated.Title.Raw != "My title" || updated.Excerpt.Raw != "Their excerpt" {
+		t.Errorf("Expected both changes to be kept, got %q and %q", updated.Title.Raw, updated.Excerpt.Raw)
+	}
+	if len(server.preconds) != 1 || server.preconds[0] != "Wed, 01 May 2024 11:00:00 GMT" {
+		t.Errorf("Expected the merged update to be based on the server version, got %v", server.preconds)
+	}
+}
+
+func TestPostsService_UpdateIfUnmodifiedBypassesCache(t *testing.T) {
+	server := newConflictServer()
+	wp, ctx := initStubClient(t, server.ServeHTTP)
+	wp.Cache = wordpress.NewResponseCache(time.Hour)
+
+	post, _, _ := wp.Posts.Get(ctx, 1, nil)
+	// cache the version check and the server version before another editor changes the post
+	if _, _, err := wp.Posts.UpdateIfUnmodified(ctx, 1, &wordpress.Post{ModifiedGMT: wordpress.TimeGMT{Time: time.Unix(1, 0)}}, nil); !errors.Is(err, wordpress.ErrConflict) {
+		t.Fatalf("Expected ErrConflict, got %v", err)
+	}
+	wp.Get(ctx, "posts/1", &wordpress.GetOptions{Fields: []string{"modified_gmt"}}, &struct{}{})
+	server.edit("Their title", "Excerpt")
+
+	post.Title.Raw = "My title"
+	_, _, err := wp.Posts.UpdateIfUnmodified(ctx, 1, post, nil)
+	var conflict *wordpress.ConflictError[wordpress.Post]
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Expected the edit to be detected despite the cache, got %v", err)
+	}
+	if conflict.Server.Title.Raw != "Their title" {
+		t.Errorf("Expected the current server version, got %q", conflict.Server.Title.Raw)
+	}
+	if len(server.puts) != 0 {
+		t.Errorf("Expected no update on conflict, got %v", server.puts)
+	}
+}
+
+func TestPagesService_UpdateIfUnmodifiedPreconditionFailed(t *testing.T) {
+	server := newConflictServer()
+	server.rejectPut = func(s *conflictServer) {
+		s.excerpt = "Their excerpt"
+		s.modified = "2024-05-01T11:00:00"
+	}
+	wp, ctx := initStubClient(t, server.ServeHTTP)
+
+	page, _, _ := wp.Pages.Get(ctx, 1, nil)
+	page.Title.Raw = "My title"
+	merges := 0
+	updated, _, err := wp.Pages.UpdateIfUnmodified(ctx, 1, page, &wordpress.ConflictOptions[wordpress.Page]{
+		Merge: func(conflict *wordpress.ConflictError[wordpress.Page]) (*wordpress.Page, error) {
+			merges++
+			return wordpress.MergeChanges(conflict)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if merges != 1 || len(server.preconds) != 2 {
+		t.Errorf("Expected one merge after the rejected update, got %v merges and %v updates", merges, len(server.preconds))
+	}
+	if updated.Title.Raw != "My title" || updated.Excerpt.Raw != "Their excerpt" {
+		t.Errorf("Expected both changes to be kept, got %q and %q", updated.Title.Raw, updated.Excerpt.Raw)
+	}
+}
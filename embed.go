@@ -0,0 +1,201 @@
+package wordpress
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// Link relations that can be embedded with Embed.
+const (
+	EmbedAuthor        = "author"
+	EmbedFeaturedMedia = "wp:featuredmedia"
+	EmbedTerm          = "wp:term"
+	EmbedReplies       = "replies"
+	EmbedUp            = "up"
+)
+
+// Embed selects the linked resources WordPress embeds in a response, as the
+// _embed parameter. Use EmbedAll to embed all embeddable links, or list the
+// link relations to embed (WordPress 5.4+), e.g.
+//
+//	opts := &wordpress.PostListOptions{ListOptions: wordpress.ListOptions{
+//		Embed: wordpress.Embed{wordpress.EmbedAuthor, wordpress.EmbedTerm},
+//	}}
+//
+// The embedded resources are decoded into the Embedded field of the entities.
+type Embed []string
+
+// EmbedAll embeds all embeddable links.
+var EmbedAll = Embed{"true"}
+
+// EncodeValues implements the query.Encoder interface.
+func (e Embed) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, strings.Join(e, ","))
+	return nil
+}
+
+// Embedded holds the resources embedded in a Post, Page, Media or Comment
+// response. Links that could not be embedded, usually because the current
+// user is not allowed to read them, are reported by Err instead. Embedded is
+// only decoded from responses and never sent back to the server.
+type Embedded struct {
+	Author        *User      // The author of the entity (rel "author").
+	FeaturedMedia *Media     // The featured media of a post or page (rel "wp:featuredmedia").
+	Terms         [][]*Term  // The terms of a post, one slice per taxonomy (rel "wp:term").
+	Replies       []*Comment // The comments on a post, or the replies to a comment (rel "replies").
+	Up            *Post      // The parent page, attachment parent or commented post (rel "up").
+
+	// Errors holds the error objects embedded instead of a resource, by link relation.
+	Errors map[string]*Error
+
+	// Raw holds the embedded resources of all link relations, including those
+	// without a typed field, e.g. of custom links.
+	Raw map[string]json.RawMessage
+}
+
+// Err returns the error embedded for the given link relation, or nil.
+func (e *Embedded) Err(rel string) error {
+	if e == nil || e.Errors[rel] == nil {
+		return nil
+	}
+	return e.Errors[rel]
+}
+
+// Decode decodes the embedded resources of the given link relation into v,
+// which must be a pointer to a slice, e.g. of custom link targets.
+func (e *Embedded) Decode(rel string, v interface{}) error {
+	if e == nil || len(e.Raw[rel]) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(e.Raw[rel], v); err != nil {
+		return err
+	}
+	fillExtra(e.Raw[rel], v)
+	return nil
+}
+
+// UnmarshalJSON decodes the _embedded object of a response.
+func (e *Embedded) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &e.Raw); err != nil {
+		return err
+	}
+
+	var author []*User
+	if err := e.decodeFirst(EmbedAuthor, &author); err != nil {
+		return err
+	}
+	if len(author) > 0 {
+		e.Author = author[0]
+	}
+
+	var media []*Media
+	if err := e.decodeFirst(EmbedFeaturedMedia, &media); err != nil {
+		return err
+	}
+	if len(media) > 0 {
+		e.FeaturedMedia = media[0]
+	}
+
+	var up []*Post
+	if err := e.decodeFirst(EmbedUp, &up); err != nil {
+		return err
+	}
+	if len(up) > 0 {
+		e.Up = up[0]
+	}
+
+	// terms and replies are embedded as one collection per link
+	terms, err := e.decodeCollections(EmbedTerm)
+	if err != nil {
+		return err
+	}
+	for _, collection := range terms {
+		var taxonomyTerms []*Term
+		if err := json.Unmarshal(collection, &taxonomyTerms); err != nil {
+			return err
+		}
+		fillExtra(collection, &taxonomyTerms)
+		e.Terms = append(e.Terms, taxonomyTerms)
+	}
+
+	replies, err := e.decodeCollections(EmbedReplies)
+	if err != nil {
+		return err
+	}
+	for _, collection := range replies {
+		var comments []*Comment
+		if err := json.Unmarshal(collection, &comments); err != nil {
+			return err
+		}
+		fillExtra(collection, &comments)
+		e.Replies = append(e.Replies, comments...)
+	}
+	return nil
+}
+
+// decodeFirst decodes the resources embedded for rel into v, a pointer to a
+// slice, recording an embedded error object instead of the first resource.
+func (e *Embedded) decodeFirst(rel string, v interface{}) error {
+	items, err := e.items(rel)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	if apiErr := embeddedError(items[0]); apiErr != nil {
+		e.addError(rel, apiErr)
+		return nil
+	}
+	if err := json.Unmarshal(e.Raw[rel], v); err != nil {
+		return err
+	}
+	fillExtra(e.Raw[rel], v)
+	return nil
+}
+
+// decodeCollections returns the collections embedded for rel, recording embedded error objects.
+func (e *Embedded) decodeCollections(rel string) ([]json.RawMessage, error) {
+	items, err := e.items(rel)
+	if err != nil {
+		return nil, err
+	}
+	var collections []json.RawMessage
+	for _, item := range items {
+		if apiErr := embeddedError(item); apiErr != nil {
+			e.addError(rel, apiErr)
+			continue
+		}
+		collections = append(collections, item)
+	}
+	return collections, nil
+}
+
+func (e *Embedded) items(rel string) ([]json.RawMessage, error) {
+	raw, ok := e.Raw[rel]
+	if !ok {
+		return nil, nil
+	}
+	var items []json.RawMessage
+	err := json.Unmarshal(raw, &items)
+	return items, err
+}
+
+func (e *Embedded) addError(rel string, err *Error) {
+	if e.Errors == nil {
+		e.Errors = make(map[string]*Error)
+	}
+	if e.Errors[rel] == nil {
+		e.Errors[rel] = err
+	}
+}
+
+// embeddedError returns the error object embedded instead of a resource, or nil.
+func embeddedError(item json.RawMessage) *Error {
+	if len(item) == 0 || item[0] != '{' {
+		return nil
+	}
+	var apiErr Error
+	if err := json.Unmarshal(item, &apiErr); err != nil || apiErr.Code == "" {
+		return nil
+	}
+	return &apiErr
+}
@@ -0,0 +1,107 @@
+package wordpress_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+const embeddedPost = `{
+	"id": 1,
+	"_embedded": {
+		"author": [{"id": 2, "name": "Jane"}],
+		"wp:featuredmedia": [{"code": "rest_forbidden", "message": "Sorry, you are not allowed to do that.", "data": {"status": 403}}],
+		"wp:term": [
+			[{"id": 3, "name": "News", "taxonomy": "category"}],
+			[{"id": 4, "name": "go", "taxonomy": "post_tag"}, {"id": 5, "name": "wp", "taxonomy": "post_tag"}]
+		],
+		"replies": [[{"id": 6, "post": 1}, {"id": 7, "post": 1}]]
+	}
+}`
+
+func TestEmbed_List(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("_embed"); got != "author,wp:featuredmedia,wp:term,replies" {
+			t.Errorf("Unexpected _embed parameter %q", got)
+		}
+		w.Write([]byte(`[` + embeddedPost + `]`))
+	})
+
+	posts, _, err := wp.Posts.List(ctx, &wordpress.PostListOptions{ListOptions: wordpress.ListOptions{
+		Embed: wordpress.Embed{wordpress.EmbedAuthor, wordpress.EmbedFeaturedMedia, wordpress.EmbedTerm, wordpress.EmbedReplies},
+	}})
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	embedded := posts[0].Embedded
+	if embedded == nil {
+		t.Fatalf("Expected embedded resources")
+	}
+	if embedded.Author == nil || embedded.Author.Name != "Jane" {
+		t.Errorf("Unexpected author %+v", embedded.Author)
+	}
+	if embedded.FeaturedMedia != nil || !errors.Is(embedded.Err(wordpress.EmbedFeaturedMedia), wordpress.ErrForbidden) {
+		t.Errorf("Expected forbidden featured media, got %+v (%v)", embedded.FeaturedMedia, embedded.Err(wordpress.EmbedFeaturedMedia))
+	}
+	if len(embedded.Terms) != 2 || len(embedded.Terms[1]) != 2 || embedded.Terms[1][1].Name != "wp" {
+		t.Errorf("Unexpected terms %+v", embedded.Terms)
+	}
+	if len(embedded.Replies) != 2 || embedded.Replies[1].ID != 7 {
+		t.Errorf("Unexpected replies %+v", embedded.Replies)
+	}
+	if embedded.Err(wordpress.EmbedAuthor) != nil {
+		t.Errorf("Expected no author error, got %v", embedded.Err(wordpress.EmbedAuthor))
+	}
+}
+
+func TestEmbed_GetAll(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("_embed"); got != "true" {
+			t.Errorf("Unexpected _embed parameter %q", got)
+		}
+		w.Write([]byte(`{"id": 6, "post": 1, "_embedded": {"up": [{"id": 1, "type": "post"}], "custom": [{"answer": 42}]}}`))
+	})
+
+	comment, _, err := wp.Comments.Get(ctx, 6, &wordpress.GetOptions{Embed: wordpress.EmbedAll})
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if comment.Embedded == nil || comment.Embedded.Up == nil || comment.Embedded.Up.ID != 1 {
+		t.Fatalf("Expected embedded post, got %+v", comment.Embedded)
+	}
+
+	var custom []struct{ Answer int }
+	if err := comment.Embedded.Decode("custom", &custom); err != nil || len(custom) != 1 || custom[0].Answer != 42 {
+		t.Errorf("Unexpected custom embed %+v (%v)", custom, err)
+	}
+}
+
+func TestEmbed_NotSent(t *testing.T) {
+	var body string
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+		}
+		w.Write([]byte(embeddedPost))
+	})
+
+	post, _, err := wp.Posts.Get(ctx, 1, &wordpress.GetOptions{Embed: wordpress.EmbedAll})
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if post.Embedded == nil || post.Embedded.Author == nil || post.Extra.Has("_embedded") {
+		t.Errorf("Expected _embedded to be decoded into Embedded only, got %+v and %v", post.Embedded, post.Extra)
+	}
+
+	wp.SendExtraFields = true
+	if _, _, err := wp.Posts.Update(ctx, 1, post); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if body != `{"id":1}`+"\n" {
+		t.Errorf("Expected no _embedded in update, got %v", body)
+	}
+}
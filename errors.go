@@ -0,0 +1,340 @@
+package wordpress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Error classes matched by *Error through errors.Is, e.g.
+//
+//	if errors.Is(err, wordpress.ErrNotFound) { ... }
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrInvalidParam = errors.New("invalid parameter")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrGone         = errors.New("gone")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+	ErrUnavailable  = errors.New("service unavailable")
+)
+
+// errorCodeClasses maps WordPress error codes to error classes. Codes which
+// WordPress returns with either 401 or 403 depending on whether the user is
+// logged in (rest_forbidden, rest_cannot_*) are classified by status instead.
+var errorCodeClasses = map[string]error{
+	// not found
+	"rest_no_route":                       ErrNotFound,
+	"rest_post_invalid_id":                ErrNotFound,
+	"rest_post_invalid_parent":            ErrNotFound,
+	"rest_comment_invalid_id":             ErrNotFound,
+	"rest_user_invalid_id":                ErrNotFound,
+	"rest_term_invalid":                   ErrNotFound,
+	"rest_taxonomy_invalid":               ErrNotFound,
+	"rest_type_invalid":                   ErrNotFound,
+	"rest_status_invalid":                 ErrNotFound,
+	"rest_revision_invalid_id":            ErrNotFound,
+	"rest_application_password_not_found": ErrNotFound,
+
+	// invalid parameters
+	"rest_invalid_param":                 ErrInvalidParam,
+	"rest_missing_callback_param":        ErrInvalidParam,
+	"rest_invalid_json":                  ErrInvalidParam,
+	"rest_post_invalid_page_number":      ErrInvalidParam,
+	"rest_comment_invalid_post_id":       ErrInvalidParam,
+	"rest_invalid_author":                ErrInvalidParam,
+	"rest_invalid_featured_media":        ErrInvalidParam,
+	"rest_upload_no_data":                ErrInvalidParam,
+	"rest_upload_no_content_type":        ErrInvalidParam,
+	"rest_upload_no_content_disposition": ErrInvalidParam,
+	"rest_upload_invalid_disposition":    ErrInvalidParam,
+	"rest_user_invalid_email":            ErrInvalidParam,
+	"rest_user_invalid_username":         ErrInvalidParam,
+	"rest_user_invalid_password":         ErrInvalidParam,
+
+	// authentication
+	"rest_not_logged_in":        ErrUnauthorized,
+	"invalid_username":          ErrUnauthorized,
+	"invalid_email":             ErrUnauthorized,
+	"incorrect_password":        ErrUnauthorized,
+	"jwt_auth_invalid_token":    ErrUnauthorized,
+	"rest_cookie_invalid_nonce": ErrForbidden,
+
+	// conflicts with existing state
+	"rest_post_exists":       ErrConflict,
+	"rest_comment_exists":    ErrConflict,
+	"rest_already_trashed":   ErrGone,
+	"term_exists":            ErrConflict,
+	"existing_user_login":    ErrConflict,
+	"existing_user_email":    ErrConflict,
+	"rest_user_exists":       ErrConflict,
+	"comment_duplicate":      ErrConflict,
+	"rest_comment_duplicate": ErrConflict,
+
+	// throttling
+	"comment_flood":      ErrRateLimited,
+	"rest_comment_flood": ErrRateLimited,
+}
+
+// errorStatusClasses maps HTTP status codes to error classes, used when the
+// error code is not in errorCodeClasses.
+var errorStatusClasses = map[int]error{
+	http.StatusBadRequest:          ErrBadRequest,
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusConflict:            ErrConflict,
+	http.StatusGone:                ErrGone,
+	http.StatusPreconditionFailed:  ErrConflict,
+	http.StatusTooManyRequests:     ErrRateLimited,
+	http.StatusInternalServerError: ErrServer,
+	http.StatusNotImplemented:      ErrServer,
+	http.StatusBadGateway:          ErrUnavailable,
+	http.StatusServiceUnavailable:  ErrUnavailable,
+	http.StatusGatewayTimeout:      ErrUnavailable,
+}
+
+// Class returns the error class of e, one of the Err* sentinels such as
+// ErrNotFound, or nil if e cannot be classified.
+func (e *Error) Class() error {
+	if class, ok := errorCodeClasses[e.Code]; ok {
+		return class
+	}
+	if strings.HasPrefix(e.Code, "rest_invalid_") {
+		return ErrInvalidParam
+	}
+	status := e.Data.Status
+	if e.Response != nil {
+		status = e.Response.StatusCode
+	}
+	if class, ok := errorStatusClasses[status]; ok {
+		return class
+	}
+	switch {
+	case status >= 500:
+		return ErrServer
+	case status >= 400:
+		return ErrBadRequest
+	}
+	return nil
+}
+
+// Is reports whether target is the error class of e, so that errors.Is(err, ErrNotFound) works on *Error.
+func (e *Error) Is(target error) bool {
+	class := e.Class()
+	return class != nil && class == target
+}
+
+// ErrorData is the data object of a WordPress error.
+type ErrorData struct {
+	Status int         `json:"status"`
+	Params ErrorParams `json:"params"`
+
+	// Details holds the structured validation error of each invalid parameter
+	// of a rest_invalid_param error, keyed by parameter name.
+	Details map[string]*ErrorDetail `json:"details"`
+}
+
+// ErrorParams maps parameter names to their error messages. WordPress sends a
+// list of names instead for rest_missing_callback_param; those names are
+// mapped to an empty message.
+type ErrorParams map[string]string
+
+// UnmarshalJSON decodes both the object and the list form of error params.
+func (p *ErrorParams) UnmarshalJSON(b []byte) error {
+	var params map[string]string
+	if err := json.Unmarshal(b, &params); err == nil {
+		*p = params
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(b, &names); err != nil {
+		return err
+	}
+	*p = make(ErrorParams, len(names))
+	for _, name := range names {
+		(*p)[name] = ""
+	}
+	return nil
+}
+
+// ErrorDetail is a nested WordPress error, as found in the details of a
+// rest_invalid_param error.
+type ErrorDetail struct {
+	Code             string          `json:"code"`
+	Message          string          `json:"message"`
+	Data             json.RawMessage `json:"data"`
+	AdditionalErrors []*ErrorDetail  `json:"additional_errors"`
+}
+
+// DecodeData decodes the data of the detail into v.
+func (d *ErrorDetail) DecodeData(v interface{}) error {
+	if len(d.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(d.Data, v)
+}
+
+// ValidationError describes why a single request parameter was rejected.
+type ValidationError struct {
+	Param   string
+	Code    string
+	Message string
+	Data    json.RawMessage
+
+	// AdditionalErrors holds further errors reported for the same parameter,
+	// e.g. one per failed schema of an anyOf/oneOf.
+	AdditionalErrors []*ErrorDetail
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Param, e.Message)
+}
+
+// ValidationErrors returns the per-parameter errors of a rest_invalid_param or
+// rest_missing_callback_param error, sorted by parameter name. It returns nil
+// for other errors.
+func (e *Error) ValidationErrors() []*ValidationError {
+	if len(e.Data.Details) == 0 && len(e.Data.Params) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(e.Data.Params))
+	for name := range e.Data.Params {
+		names = append(names, name)
+	}
+	for name := range e.Data.Details {
+		if _, ok := e.Data.Params[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	errs := make([]*ValidationError, len(names))
+	for i, name := range names {
+		v := &ValidationError{
+			Param:   name,
+			Code:    e.Code,
+			Message: e.Data.Params[name],
+		}
+		if detail := e.Data.Details[name]; detail != nil {
+			v.Code = detail.Code
+			v.Message = detail.Message
+			v.Data = detail.Data
+			v.AdditionalErrors = detail.AdditionalErrors
+		}
+		if v.Message == "" {
+			v.Message = e.Message
+		}
+		errs[i] = v
+	}
+	return errs
+}
+
+// maxErrorSnippet is the maximum number of bytes of a non-JSON error body kept in UnexpectedResponseError.
+const maxErrorSnippet = 512
+
+// ResponseKind describes what kind of page a non-JSON error response is.
+type ResponseKind string
+
+// Kinds of non-JSON error responses detected by checkResponse.
+const (
+	ResponseKindUnknown      ResponseKind = "unknown"
+	ResponseKindMaintenance  ResponseKind = "maintenance"
+	ResponseKindWAFChallenge ResponseKind = "waf_challenge"
+	ResponseKindPHPFatal     ResponseKind = "php_fatal_error"
+)
+
+// UnexpectedResponseError is returned when an error response does not carry a
+// WordPress JSON error body, which usually means the request never reached the
+// REST API: a maintenance page, a WAF/CDN challenge or a PHP fatal error page.
+type UnexpectedResponseError struct {
+	Response    *http.Response // HTTP response that caused this error
+	StatusCode  int
+	ContentType string
+	Kind        ResponseKind
+	Snippet     string // Start of the response body, truncated to 512 bytes.
+}
+
+func (e *UnexpectedResponseError) Error() string {
+	msg := fmt.Sprintf("%d non-JSON response", e.StatusCode)
+	if e.Kind != ResponseKindUnknown {
+		msg += fmt.Sprintf(" (%v)", e.Kind)
+	}
+	if e.Response != nil && e.Response.Request != nil {
+		msg = fmt.Sprintf("%v %v: %v", e.Response.Request.Method, sanitizeURL(e.Response.Request.URL), msg)
+	}
+	return fmt.Sprintf("%v: %q", msg, e.Snippet)
+}
+
+// Is matches the error class of the response status, and ErrUnavailable for maintenance pages.
+func (e *UnexpectedResponseError) Is(target error) bool {
+	if e.Kind == ResponseKindMaintenance {
+		return target == ErrUnavailable
+	}
+	class, ok := errorStatusClasses[e.StatusCode]
+	if !ok && e.StatusCode >= 500 {
+		class = ErrServer
+	}
+	return class != nil && class == target
+}
+
+// wafMarkers are strings found in the challenge and block pages of common WAFs and CDNs.
+var wafMarkers = []string{
+	"cf-chl", "challenge-platform", "attention required! | cloudflare", "just a moment...",
+	"sucuri website firewall", "wordfence", "mod_security", "modsecurity",
+	"request unsuccessful. incapsula",
+}
+
+// isWAFPage reports whether r, with the lower-cased body, is a WAF or CDN
+// challenge or block page.
+func isWAFPage(r *http.Response, lower string) bool {
+	if r.Header.Get("Cf-Mitigated") == "challenge" || r.Header.Get("Server") == "AkamaiGHost" {
+		return true
+	}
+	// the Akamai block page is titled "Access Denied" and ends with a reference
+	// number, e.g. "Reference #18.2d351ab8.1557333295.a4e16ab"
+	if strings.Contains(lower, "<title>access denied</title>") && strings.Contains(lower, "reference #") {
+		return true
+	}
+	for _, marker := range wafMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// newUnexpectedResponseError builds an UnexpectedResponseError from a non-JSON error body.
+func newUnexpectedResponseError(r *http.Response, body []byte) *UnexpectedResponseError {
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if len(snippet) > maxErrorSnippet {
+		snippet = strings.ToValidUTF8(snippet[:maxErrorSnippet], "")
+	}
+	e := &UnexpectedResponseError{
+		Response:    r,
+		StatusCode:  r.StatusCode,
+		ContentType: r.Header.Get("Content-Type"),
+		Kind:        ResponseKindUnknown,
+		Snippet:     snippet,
+	}
+
+	lower := strings.ToLower(string(body))
+	switch {
+	case strings.Contains(lower, "briefly unavailable for scheduled maintenance"):
+		e.Kind = ResponseKindMaintenance
+	case strings.Contains(lower, "fatal error") || strings.Contains(lower, "parse error:") ||
+		strings.Contains(lower, "critical error on this website"):
+		e.Kind = ResponseKindPHPFatal
+	case isWAFPage(r, lower):
+		e.Kind = ResponseKindWAFChallenge
+	}
+	return e
+}
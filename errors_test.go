@@ -0,0 +1,142 @@
+package wordpress_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestError_Classes(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		class  error
+	}{
+		{404, `{"code":"rest_post_invalid_id","message":"Invalid post ID.","data":{"status":404}}`, wordpress.ErrNotFound},
+		{401, `{"code":"rest_cannot_create","message":"Sorry, you are not allowed to create posts as this user.","data":{"status":401}}`, wordpress.ErrUnauthorized},
+		{403, `{"code":"rest_cannot_create","message":"Sorry, you are not allowed to create posts as this user.","data":{"status":403}}`, wordpress.ErrForbidden},
+		{400, `{"code":"rest_invalid_param","message":"Invalid parameter(s): status","data":{"status":400}}`, wordpress.ErrInvalidParam},
+		{400, `{"code":"term_exists","message":"A term with the name provided already exists.","data":{"status":400}}`, wordpress.ErrConflict},
+		{429, `{"code":"too_many_requests","message":"Slow down","data":{"status":429}}`, wordpress.ErrRateLimited},
+		{503, `{"code":"maintenance","message":"Down","data":{"status":503}}`, wordpress.ErrUnavailable},
+	}
+
+	for _, tc := range cases {
+		wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.status)
+			w.Write([]byte(tc.body))
+		})
+
+		_, _, err := wp.Posts.Get(ctx, 1, nil)
+		if !errors.Is(err, tc.class) {
+			t.Errorf("%v: expected errors.Is(err, %v), got %v", tc.body, tc.class, err)
+		}
+		if tc.class != wordpress.ErrNotFound && errors.Is(err, wordpress.ErrNotFound) {
+			t.Errorf("%v: should not match ErrNotFound", tc.body)
+		}
+
+		var wpErr *wordpress.Error
+		if !errors.As(err, &wpErr) || wpErr.Class() != tc.class {
+			t.Errorf("%v: expected *wordpress.Error with class %v, got %v", tc.body, tc.class, err)
+		}
+	}
+}
+
+func TestError_ValidationErrors(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"rest_invalid_param","message":"Invalid parameter(s): status, tags","data":{"status":400,` +
+			`"params":{"status":"status is not one of publish, future, draft, pending, private.","tags":"tags[0] is not of type integer."},` +
+			`"details":{"status":{"code":"rest_not_in_enum","message":"status is not one of publish, future, draft, pending, private.","data":null},` +
+			`"tags":{"code":"rest_invalid_type","message":"tags[0] is not of type integer.","data":{"param":"tags[0]"}}}}}`))
+	})
+
+	p := factoryPost()
+	_, _, err := wp.Posts.Create(ctx, &p)
+	var wpErr *wordpress.Error
+	if !errors.As(err, &wpErr) {
+		t.Fatalf("Expected *wordpress.Error, got %v", err)
+	}
+
+	validationErrs := wpErr.ValidationErrors()
+	if len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 validation errors, got %v", len(validationErrs))
+	}
+	if validationErrs[0].Param != "status" || validationErrs[0].Code != "rest_not_in_enum" {
+		t.Errorf("Unexpected first validation error: %+v", validationErrs[0])
+	}
+	if validationErrs[1].Param != "tags" || validationErrs[1].Code != "rest_invalid_type" {
+		t.Errorf("Unexpected second validation error: %+v", validationErrs[1])
+	}
+
+	var data struct {
+		Param string `json:"param"`
+	}
+	if err := wpErr.Data.Details["tags"].DecodeData(&data); err != nil || data.Param != "tags[0]" {
+		t.Errorf("Expected nested data param tags[0], got %q (%v)", data.Param, err)
+	}
+}
+
+func TestError_MissingParams(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"rest_missing_callback_param","message":"Missing parameter(s): name","data":{"status":400,"params":["name"]}}`))
+	})
+
+	_, _, err := wp.Tags.Create(ctx, &wordpress.Tag{})
+	var wpErr *wordpress.Error
+	if !errors.As(err, &wpErr) {
+		t.Fatalf("Expected *wordpress.Error, got %v", err)
+	}
+	validationErrs := wpErr.ValidationErrors()
+	if len(validationErrs) != 1 || validationErrs[0].Param != "name" || validationErrs[0].Message != "Missing parameter(s): name" {
+		t.Errorf("Unexpected validation errors: %+v", validationErrs)
+	}
+}
+
+func TestError_NonJSONBodies(t *testing.T) {
+	cases := []struct {
+		status int
+		header http.Header
+		body   string
+		kind   wordpress.ResponseKind
+		class  error
+	}{
+		{503, nil, `<!DOCTYPE html><html><head><title>Maintenance</title></head><body>Briefly unavailable for scheduled maintenance. Check back in a minute.</body></html>`, wordpress.ResponseKindMaintenance, wordpress.ErrUnavailable},
+		{403, http.Header{"Cf-Mitigated": {"challenge"}}, `<!DOCTYPE html><html><head><title>Just a moment...</title></head><body></body></html>`, wordpress.ResponseKindWAFChallenge, wordpress.ErrForbidden},
+		{403, http.Header{"Server": {"AkamaiGHost"}}, `<HTML><HEAD><TITLE>Forbidden</TITLE></HEAD><BODY></BODY></HTML>`, wordpress.ResponseKindWAFChallenge, wordpress.ErrForbidden},
+		{403, nil, `<HTML><HEAD><TITLE>Access Denied</TITLE></HEAD><BODY><H1>Access Denied</H1>You don't have permission to access this server.<P>Reference #18.2d351ab8.1557333295.a4e16ab</BODY></HTML>`, wordpress.ResponseKindWAFChallenge, wordpress.ErrForbidden},
+		{403, nil, `<html><head><title>403 Forbidden</title></head><body><h1>Access denied</h1><p>Ask the Akamai team for access.</p></body></html>`, wordpress.ResponseKindUnknown, wordpress.ErrForbidden},
+		{500, nil, `<br /><b>Fatal error</b>: Uncaught Error: Call to undefined function foo() in /var/www/wp-content/plugins/bar.php:12`, wordpress.ResponseKindPHPFatal, wordpress.ErrServer},
+		{502, nil, `<html><body><h1>502 Bad Gateway</h1></body></html>`, wordpress.ResponseKindUnknown, wordpress.ErrUnavailable},
+	}
+
+	for _, tc := range cases {
+		wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+			for name, values := range tc.header {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(tc.status)
+			w.Write([]byte(tc.body))
+		})
+
+		_, resp, err := wp.Posts.List(ctx, nil)
+		var unexpected *wordpress.UnexpectedResponseError
+		if !errors.As(err, &unexpected) {
+			t.Errorf("%v: expected *wordpress.UnexpectedResponseError, got %v", tc.body, err)
+			continue
+		}
+		if unexpected.Kind != tc.kind || unexpected.StatusCode != tc.status || unexpected.ContentType != "text/html; charset=UTF-8" {
+			t.Errorf("%v: unexpected error fields %+v", tc.body, unexpected)
+		}
+		if !errors.Is(err, tc.class) {
+			t.Errorf("%v: expected errors.Is(err, %v)", tc.body, tc.class)
+		}
+		if resp == nil || resp.StatusCode != tc.status {
+			t.Errorf("%v: expected response with status %v, got %v", tc.body, tc.status, resp)
+		}
+	}
+}
@@ -5,30 +5,27 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/dghubble/oauth1"
 	"github.com/robbiet480/go-wordpress"
 )
 
-var config oauth1.Config
+var config *wordpress.OAuth1Config
 
 // main performs the WordPress OAuth1 user flow from the command line
 func main() {
-	config = oauth1.Config{
+	config = &wordpress.OAuth1Config{
 		ConsumerKey:    "CONSUMER_KEY",
 		ConsumerSecret: "CONSUMER_SECRET",
 		CallbackURL:    "http://localhost:8080/callback",
-		Endpoint: oauth1.Endpoint{
-			RequestTokenURL: "http://192.168.99.100:32777/oauth1/request",
-			AuthorizeURL:    "http://192.168.99.100:32777/oauth1/authorize",
-			AccessTokenURL:  "http://192.168.99.100:32777/oauth1/access",
-		},
+		Endpoint:       wordpress.WordPressOAuth1Endpoint("http://192.168.99.100:32777"),
 	}
 
-	requestToken, requestSecret, err := login()
+	ctx := context.Background()
+
+	requestToken, err := login(ctx)
 	if err != nil {
 		log.Fatalf("Request Token Phase: %s", err.Error())
 	}
-	accessToken, err := receiveVerifier(requestToken, requestSecret)
+	accessToken, err := receiveVerifier(ctx, requestToken)
 	if err != nil {
 		log.Fatalf("Access Token Phase: %s", err.Error())
 	}
@@ -36,12 +33,14 @@ func main() {
 	log.Println("Consumer was granted an access token to act on behalf of a user.")
 	log.Printf("token: %s\nsecret: %s\n", accessToken.Token, accessToken.TokenSecret)
 
-	ctx := context.Background()
-
-	httpClient := config.Client(ctx, accessToken)
+	// store the access token; implement wordpress.OAuth1TokenStore to persist it
+	tp := &wordpress.OAuth1Transport{
+		Config: config,
+		Store:  wordpress.NewMemoryOAuth1TokenStore(accessToken),
+	}
 
 	// create wp-api client
-	client, _ := wordpress.NewClient("http://192.168.99.100:32777/wp-json/", httpClient)
+	client, _ := wordpress.NewClient("http://192.168.99.100:32777/wp-json/", tp.Client())
 
 	// get the currently authenticated users details
 	authenticatedUser, _, err := client.Users.Me(ctx, nil)
@@ -51,27 +50,25 @@ func main() {
 	log.Printf("Authenticated user %+v", authenticatedUser)
 }
 
-func login() (requestToken, requestSecret string, err error) {
-	requestToken, requestSecret, err = config.RequestToken()
+func login(ctx context.Context) (*wordpress.OAuth1Token, error) {
+	requestToken, err := config.RequestToken(ctx)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 	authorizationURL, err := config.AuthorizationURL(requestToken)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 	fmt.Printf("Open this URL in your browser:\n%s\n", authorizationURL.String())
-	return requestToken, requestSecret, err
+	return requestToken, nil
 }
 
-func receiveVerifier(requestToken, requestSecret string) (*oauth1.Token, error) {
+func receiveVerifier(ctx context.Context, requestToken *wordpress.OAuth1Token) (*wordpress.OAuth1Token, error) {
 	fmt.Printf("Choose whether to grant the application access.\nPaste " +
 		"the oauth_verifier parameter from the address bar: ")
 	var verifier string
-	_, err := fmt.Scanf("%s", &verifier)
-	accessToken, accessSecret, err := config.AccessToken(requestToken, requestSecret, verifier)
-	if err != nil {
+	if _, err := fmt.Scanf("%s", &verifier); err != nil {
 		return nil, err
 	}
-	return oauth1.NewToken(accessToken, accessSecret), err
+	return config.AccessToken(ctx, requestToken, verifier)
 }
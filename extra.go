@@ -0,0 +1,263 @@
+package wordpress
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ExtraFields holds the fields of an entity which have no struct field, e.g.
+// fields added by plugins with register_rest_field, by their JSON name.
+//
+// It is filled when the entity is decoded from a response of the Client,
+// including the entities of a Batch and embedded entities. Set
+// Client.SendExtraFields to send the fields back when the entity is created or updated.
+type ExtraFields map[string]json.RawMessage
+
+// Has reports whether the field with the given name is present.
+func (e ExtraFields) Has(name string) bool {
+	_, ok := e[name]
+	return ok
+}
+
+// Decode decodes the field with the given name into v, e.g. a struct of its
+// own. It leaves v untouched if the field is missing.
+func (e ExtraFields) Decode(name string, v interface{}) error {
+	raw, ok := e[name]
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// Set sets the field with the given name to the JSON encoding of value.
+func (e *ExtraFields) Set(name string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if *e == nil {
+		*e = make(ExtraFields)
+	}
+	(*e)[name] = raw
+	return nil
+}
+
+var extraFieldsType = reflect.TypeOf(ExtraFields(nil))
+
+// knownFields caches the lower-cased JSON names of the fields of struct types.
+var knownFields sync.Map // map[reflect.Type]map[string]bool
+
+// jsonFieldNames returns the lower-cased JSON names of the fields of struct
+// type t, including those promoted from embedded structs.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if names, ok := knownFields.Load(t); ok {
+		return names.(map[string]bool)
+	}
+
+	names := make(map[string]bool)
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+				walk(ft)
+				continue
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			names[strings.ToLower(name)] = true
+		}
+	}
+	walk(t)
+
+	knownFields.Store(t, names)
+	return names
+}
+
+// responseFields maps the JSON names of response-only fields to the names of
+// the struct fields they are decoded into. The struct fields are tagged
+// json:"-", so that they are never sent back to the server, and are filled by fillExtra.
+var responseFields = map[string]string{
+	"_links":    "Links",
+	"_embedded": "Embedded",
+}
+
+// trackedType is the interface of the entities with change tracking.
+var trackedType = reflect.TypeOf((*interface{ changeTracker() *tracker })(nil)).Elem()
+
+// fillableTypes caches the results of fillable.
+var fillableTypes sync.Map // map[reflect.Type]bool
+
+// fillable reports whether fillExtra has anything to fill in values of type t,
+// i.e. whether t is a struct with an Extra field, a response-only field or
+// change tracking, or a pointer to or slice of such structs.
+func fillable(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	if ok, cached := fillableTypes.Load(t); cached {
+		return ok.(bool)
+	}
+
+	ok := reflect.PointerTo(t).Implements(trackedType)
+	if f, found := t.FieldByName("Extra"); found && f.Type == extraFieldsType {
+		ok = true
+	}
+	for _, fieldName := range responseFields {
+		if _, found := t.FieldByName(fieldName); found {
+			ok = true
+		}
+	}
+	fillableTypes.Store(t, ok)
+	return ok
+}
+
+// namedField returns the field with the given name of struct value v, or the
+// zero Value. typ is the required type of the field, if not nil.
+func namedField(v reflect.Value, name string, typ reflect.Type) reflect.Value {
+	f, ok := v.Type().FieldByName(name)
+	if !ok || (typ != nil && f.Type != typ) {
+		return reflect.Value{}
+	}
+	field, err := v.FieldByIndexErr(f.Index)
+	if err != nil {
+		// promoted through a nil embedded pointer
+		return reflect.Value{}
+	}
+	return field
+}
+
+// extraField returns the ExtraFields of struct value v, or the zero Value.
+func extraField(v reflect.Value) reflect.Value {
+	return namedField(v, "Extra", extraFieldsType)
+}
+
+// fillExtra sets the ExtraFields of the entities decoded from data into v to
+// the fields of data without a struct field, and decodes the response-only
+// fields such as _links. Entities with change tracking keep their part of data
+// for their snapshot. v may point to an entity or to a slice of entities;
+// values of other types are left untouched without decoding data again.
+func fillExtra(data []byte, v interface{}) {
+	fillExtraValue(data, reflect.ValueOf(v))
+}
+
+func fillExtraValue(data []byte, v reflect.Value) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	// skip decoding data again for everything but entities
+	if !fillable(v.Type()) {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return
+		}
+		for i := 0; i < len(items) && i < v.Len(); i++ {
+			fillExtraValue(items[i], v.Index(i))
+		}
+
+	case reflect.Struct:
+		if v.CanAddr() {
+			if tracked, ok := v.Addr().Interface().(interface{ changeTracker() *tracker }); ok {
+				tracked.changeTracker().track(data)
+			}
+		}
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(data, &fields) != nil {
+			return
+		}
+
+		for name, fieldName := range responseFields {
+			raw, ok := fields[name]
+			field := namedField(v, fieldName, nil)
+			if !ok || !field.IsValid() || !field.CanSet() {
+				continue
+			}
+			value := reflect.New(field.Type())
+			if json.Unmarshal(raw, value.Interface()) == nil {
+				field.Set(value.Elem())
+			}
+		}
+
+		extra := extraField(v)
+		if !extra.IsValid() || !extra.CanSet() {
+			return
+		}
+		known := jsonFieldNames(v.Type())
+		unknown := ExtraFields{}
+		for name, raw := range fields {
+			if _, ok := responseFields[name]; ok {
+				continue
+			}
+			if !known[strings.ToLower(name)] {
+				unknown[name] = raw
+			}
+		}
+		if len(unknown) > 0 {
+			extra.Set(reflect.ValueOf(unknown))
+		}
+	}
+}
+
+// mergeExtra adds the ExtraFields of body to its JSON encoding data, unless
+// data already has a field of the same name.
+func mergeExtra(data []byte, body interface{}) ([]byte, error) {
+	v := reflect.ValueOf(body)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return data, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return data, nil
+	}
+	extra := extraField(v)
+	if !extra.IsValid() || extra.Len() == 0 {
+		return data, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, raw := range extra.Interface().(ExtraFields) {
+		if _, ok := fields[name]; !ok {
+			fields[name] = raw
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(fields); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,67 @@
+package wordpress_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestExtraFields_RoundTrip(t *testing.T) {
+	var updates []map[string]json.RawMessage
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"id":1,"slug":"hello","seo":{"title":"Hello | Site","noindex":false},"reading_time":4}`))
+			return
+		}
+		var body map[string]json.RawMessage
+		json.NewDecoder(r.Body).Decode(&body)
+		updates = append(updates, body)
+		w.Write([]byte(`{"id":1}`))
+	})
+
+	post, _, err := wp.Posts.Get(ctx, 1, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if len(post.Extra) != 2 || post.Extra.Has("slug") || !post.Extra.Has("reading_time") {
+		t.Errorf("Unexpected extra fields %v", post.Extra)
+	}
+	var seo struct {
+		Title   string
+		NoIndex bool
+	}
+	if err := post.Extra.Decode("seo", &seo); err != nil || seo.Title != "Hello | Site" {
+		t.Errorf("Unexpected seo field %+v (%v)", seo, err)
+	}
+
+	post.Extra.Set("reading_time", 5)
+	wp.Posts.Update(ctx, 1, post)
+	if _, ok := updates[0]["reading_time"]; ok {
+		t.Errorf("Extra fields should only be sent with SendExtraFields")
+	}
+
+	wp.SendExtraFields = true
+	wp.Posts.Update(ctx, 1, post)
+	if string(updates[1]["reading_time"]) != "5" || string(updates[1]["slug"]) != `"hello"` || updates[1]["seo"] == nil {
+		t.Errorf("Expected merged extra fields, got %v", updates[1])
+	}
+}
+
+func TestExtraFields_EmbeddingStruct(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":1,"venue":"Berlin","capacity":200},{"id":2,"venue":"Paris"}]`))
+	})
+
+	events, _, err := wordpress.NewPostTypeService[event](wp, "events").List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if events[0].Venue != "Berlin" || len(events[0].Extra) != 1 || string(events[0].Extra["capacity"]) != "200" {
+		t.Errorf("Unexpected event %+v", events[0])
+	}
+	if events[1].Extra != nil {
+		t.Errorf("Expected no extra fields, got %v", events[1].Extra)
+	}
+}
@@ -0,0 +1,65 @@
+package wordpress_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestFields_BothPermalinkModes(t *testing.T) {
+	for _, nonPretty := range []bool{false, true} {
+		wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if nonPretty && q.Get("rest_route") != "/wp/v2/posts" {
+				t.Errorf("Unexpected rest_route %q", q.Get("rest_route"))
+			}
+			if got := q.Get("_fields"); got != "id,slug,meta.foo,_links" {
+				t.Errorf("Unexpected _fields %q (non-pretty: %v)", got, nonPretty)
+			}
+			w.Write([]byte(`[{"id":1,"slug":"hello"}]`))
+		})
+		wp.NonPrettyPermalinks = nonPretty
+
+		posts, _, err := wp.Posts.List(ctx, &wordpress.PostListOptions{ListOptions: wordpress.ListOptions{
+			Fields: []string{"id", "slug", "meta.foo", "_links"},
+		}})
+		if err != nil || len(posts) != 1 || posts[0].Slug != "hello" {
+			t.Errorf("Unexpected posts %+v (%v)", posts, err)
+		}
+	}
+}
+
+func TestFields_PartialRoundTrip(t *testing.T) {
+	var updateBody map[string]json.RawMessage
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			if got := r.URL.Query().Get("_fields"); got != "id,title" {
+				t.Errorf("Unexpected _fields %q", got)
+			}
+			w.Write([]byte(`{"id":1,"title":{"raw":"Hello","rendered":"Hello"}}`))
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&updateBody)
+		w.Write([]byte(`{"id":1}`))
+	})
+
+	post, _, err := wp.Posts.Get(ctx, 1, &wordpress.GetOptions{Fields: []string{"id", "title"}})
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	post.Title.Raw = "Hello again"
+	if _, _, err := wp.Posts.Update(ctx, post.ID, post); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+
+	for _, key := range []string{"date", "date_gmt", "modified", "content", "excerpt", "guid"} {
+		if _, ok := updateBody[key]; ok {
+			t.Errorf("Missing field %q should not be sent, got %s", key, updateBody[key])
+		}
+	}
+	if string(updateBody["title"]) != `{"raw":"Hello again","rendered":"Hello"}` {
+		t.Errorf("Unexpected title %s", updateBody["title"])
+	}
+}
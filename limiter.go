@@ -0,0 +1,185 @@
+package wordpress
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Limiter throttles the requests sent by a Client. Acquire is called before
+// every attempt of every request, including retries, and blocks until the
+// request may be sent or ctx is done. The returned release function is called
+// once the response body has been closed.
+type Limiter interface {
+	Acquire(ctx context.Context) (release func(), err error)
+}
+
+// LimiterStats is a snapshot of the state of a RateLimiter.
+type LimiterStats struct {
+	InFlight  int     // Requests currently holding a concurrency slot.
+	Waiting   int     // Requests currently blocked in Acquire.
+	Tokens    float64 // Tokens currently available in the bucket.
+	Acquired  int64   // Total number of successful Acquire calls.
+	Cancelled int64   // Total number of Acquire calls aborted by their context.
+}
+
+// RateLimiter is a Limiter combining a token bucket, limiting the number of
+// requests per second, with a semaphore limiting the number of requests in
+// flight. It is safe for concurrent use by multiple goroutines.
+type RateLimiter struct {
+	rate  float64 // tokens added per second; 0 disables rate limiting
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+
+	slots chan struct{} // nil disables concurrency limiting
+
+	waiting   int32
+	acquired  int64
+	cancelled int64
+}
+
+// NewRateLimiter returns a RateLimiter allowing requestsPerSecond requests per
+// second with bursts of up to burst requests, and at most maxInFlight
+// concurrent requests. A zero requestsPerSecond or maxInFlight disables the
+// corresponding limit.
+func NewRateLimiter(requestsPerSecond float64, burst int, maxInFlight int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	l := &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+	if maxInFlight > 0 {
+		l.slots = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// Acquire implements the Limiter interface.
+func (l *RateLimiter) Acquire(ctx context.Context) (func(), error) {
+	atomic.AddInt32(&l.waiting, 1)
+	defer atomic.AddInt32(&l.waiting, -1)
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			atomic.AddInt64(&l.cancelled, 1)
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := l.waitToken(ctx); err != nil {
+		l.releaseSlot()
+		atomic.AddInt64(&l.cancelled, 1)
+		return nil, err
+	}
+
+	atomic.AddInt64(&l.acquired, 1)
+	var once sync.Once
+	return func() { once.Do(l.releaseSlot) }, nil
+}
+
+// Stats returns a snapshot of the limiter state, e.g. for progress reporting.
+func (l *RateLimiter) Stats() LimiterStats {
+	l.mu.Lock()
+	l.refill(time.Now())
+	tokens := l.tokens
+	l.mu.Unlock()
+
+	return LimiterStats{
+		InFlight:  len(l.slots),
+		Waiting:   int(atomic.LoadInt32(&l.waiting)),
+		Tokens:    tokens,
+		Acquired:  atomic.LoadInt64(&l.acquired),
+		Cancelled: atomic.LoadInt64(&l.cancelled),
+	}
+}
+
+func (l *RateLimiter) releaseSlot() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// refill adds the tokens accumulated since the last call. l.mu must be held.
+func (l *RateLimiter) refill(now time.Time) {
+	if l.rate <= 0 {
+		return
+	}
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+}
+
+// waitToken takes a token from the bucket, sleeping until one is available.
+func (l *RateLimiter) waitToken(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+	for {
+		l.mu.Lock()
+		l.refill(time.Now())
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// releaseOnClose calls release when the wrapped body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// roundTrip sends a single attempt of req, holding the client Limiter for the
+// lifetime of the response body.
+func (c *Client) roundTrip(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if trace := traceFromContext(ctx); trace != nil {
+		atomic.AddInt32(&trace.attempts, 1)
+	}
+	if c.Limiter == nil {
+		return c.client.Do(req)
+	}
+
+	release, err := c.Limiter.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
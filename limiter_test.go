@@ -0,0 +1,86 @@
+package wordpress_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestRateLimiter_MaxInFlight(t *testing.T) {
+	var inFlight, maxSeen int32
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxSeen)
+			if n <= m || atomic.CompareAndSwapInt32(&maxSeen, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`[]`))
+	})
+	limiter := wordpress.NewRateLimiter(0, 0, 2)
+	wp.Limiter = limiter
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := wp.Users.List(ctx, nil); err != nil {
+				t.Errorf("Should not return error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxSeen > 2 {
+		t.Errorf("Expected at most 2 requests in flight, saw %v", maxSeen)
+	}
+	stats := limiter.Stats()
+	if stats.InFlight != 0 || stats.Acquired != 8 {
+		t.Errorf("Unexpected limiter stats after completion: %+v", stats)
+	}
+}
+
+func TestRateLimiter_RequestsPerSecond(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	})
+	wp.Limiter = wordpress.NewRateLimiter(20, 1, 0)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, _, err := wp.Tags.List(ctx, nil); err != nil {
+			t.Fatalf("Should not return error: %v", err)
+		}
+	}
+	// the first request uses the initial token, the other four wait 50ms each
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("Expected requests to be throttled, took only %v", elapsed)
+	}
+}
+
+func TestRateLimiter_HonorsContext(t *testing.T) {
+	limiter := wordpress.NewRateLimiter(0, 0, 1)
+	release, err := limiter.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Acquire(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if stats := limiter.Stats(); stats.InFlight != 1 || stats.Cancelled != 1 {
+		t.Errorf("Unexpected limiter stats: %+v", stats)
+	}
+}
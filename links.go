@@ -0,0 +1,158 @@
+package wordpress
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Link relations commonly found in the _links of WordPress resources.
+const (
+	LinkSelf               = "self"
+	LinkCollection         = "collection"
+	LinkAbout              = "about"
+	LinkAuthor             = "author"
+	LinkReplies            = "replies"
+	LinkUp                 = "up"
+	LinkVersionHistory     = "version-history"
+	LinkPredecessorVersion = "predecessor-version"
+	LinkFeaturedMedia      = "wp:featuredmedia"
+	LinkAttachment         = "wp:attachment"
+	LinkTerm               = "wp:term"
+	LinkCuries             = "curies"
+
+	linkActionPrefix = "wp:action-"
+)
+
+// ErrTemplatedLink is returned from Client.Follow for templated links, such as curies.
+var ErrTemplatedLink = errors.New("cannot follow a templated link")
+
+// ErrNoLink is returned from Client.Follow for a nil link, e.g. from Links.Get of a missing relation.
+var ErrNoLink = errors.New("no link to follow")
+
+// Link is a single HAL link of a WordPress resource.
+type Link struct {
+	Href       string `json:"href"`
+	Embeddable bool   `json:"embeddable,omitempty"` // Whether the target can be embedded with Embed.
+	Templated  bool   `json:"templated,omitempty"`
+	Name       string `json:"name,omitempty"`     // Name of a curie.
+	Taxonomy   string `json:"taxonomy,omitempty"` // Taxonomy of a wp:term link.
+	Count      int    `json:"count,omitempty"`    // Number of revisions of a version-history link.
+	ID         int    `json:"id,omitempty"`       // ID of the target of a predecessor-version link.
+
+	// TargetHints describes the target, e.g. the "allow"ed methods of a self link (WordPress 5.5+).
+	TargetHints map[string][]string `json:"targetHints,omitempty"`
+}
+
+// Allows reports whether the targetHints of the link allow the given method.
+// It returns false if the link has no allow hint.
+func (l *Link) Allows(method string) bool {
+	for _, allowed := range l.TargetHints["allow"] {
+		if strings.EqualFold(allowed, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// Links holds the _links of a WordPress resource, by link relation. Links are
+// only decoded from responses and never sent back to the server.
+type Links map[string][]*Link
+
+// Get returns the first link of the given relation, or nil. Relations may be
+// given in their full form, e.g. "https://api.w.org/term", which is compacted
+// with the curies of the links.
+func (l Links) Get(rel string) *Link {
+	links := l.All(rel)
+	if len(links) == 0 {
+		return nil
+	}
+	return links[0]
+}
+
+// All returns all links of the given relation.
+func (l Links) All(rel string) []*Link {
+	if links, ok := l[rel]; ok {
+		return links
+	}
+	for _, curie := range l[LinkCuries] {
+		prefix, suffix, ok := strings.Cut(curie.Href, "{rel}")
+		if ok && strings.HasPrefix(rel, prefix) && strings.HasSuffix(rel, suffix) {
+			compact := curie.Name + ":" + strings.TrimSuffix(strings.TrimPrefix(rel, prefix), suffix)
+			if links, ok := l[compact]; ok {
+				return links
+			}
+		}
+	}
+	return nil
+}
+
+// Term returns the wp:term link of the given taxonomy, e.g. "category", or nil.
+func (l Links) Term(taxonomy string) *Link {
+	for _, link := range l[LinkTerm] {
+		if link.Taxonomy == taxonomy {
+			return link
+		}
+	}
+	return nil
+}
+
+// Embeddable returns the sorted relations which have at least one embeddable link.
+func (l Links) Embeddable() []string {
+	var rels []string
+	for rel, links := range l {
+		for _, link := range links {
+			if link.Embeddable {
+				rels = append(rels, rel)
+				break
+			}
+		}
+	}
+	sort.Strings(rels)
+	return rels
+}
+
+// Actions returns the sorted actions the current user may perform on the
+// resource, from the wp:action-* relations, e.g. "publish" or "assign-categories".
+// Actions are only included in responses requested with the edit context.
+func (l Links) Actions() []string {
+	var actions []string
+	for rel := range l {
+		if strings.HasPrefix(rel, linkActionPrefix) {
+			actions = append(actions, strings.TrimPrefix(rel, linkActionPrefix))
+		}
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// Can reports whether the links include the wp:action-<action> relation.
+func (l Links) Can(action string) bool {
+	_, ok := l[linkActionPrefix+action]
+	return ok
+}
+
+// Follow fetches the target of link and decodes it into v, like Get. Unlike
+// the service methods, the target may be any route of the REST API, not only
+// /wp/v2. The request is sent with the http.Client of c, including its
+// authentication, so only follow links of trusted sites.
+func (c *Client) Follow(ctx context.Context, link *Link, v interface{}) (*Response, error) {
+	if link == nil {
+		return nil, ErrNoLink
+	}
+	if link.Templated {
+		return nil, ErrTemplatedLink
+	}
+	u, err := url.Parse(link.Href)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(http.MethodGet, c.baseURL.ResolveReference(u), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, v)
+}
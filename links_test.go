@@ -0,0 +1,103 @@
+package wordpress_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestLinks_Follow(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		base := "http://" + r.Host
+		switch r.URL.Path {
+		case "/wp-json/wp/v2/posts/1":
+			fmt.Fprintf(w, `{"id": 1, "_links": {
+				"self": [{"href": "%[1]s/wp-json/wp/v2/posts/1", "targetHints": {"allow": ["GET", "POST", "PUT", "PATCH", "DELETE"]}}],
+				"author": [{"embeddable": true, "href": "%[1]s/wp-json/wp/v2/users/2"}],
+				"wp:term": [
+					{"taxonomy": "category", "embeddable": true, "href": "%[1]s/wp-json/wp/v2/categories?post=1"},
+					{"taxonomy": "post_tag", "embeddable": true, "href": "%[1]s/wp-json/wp/v2/tags?post=1"}
+				],
+				"version-history": [{"count": 3, "href": "%[1]s/wp-json/wp/v2/posts/1/revisions"}],
+				"wp:action-publish": [{"href": "%[1]s/wp-json/wp/v2/posts/1"}],
+				"wp:action-sticky": [{"href": "%[1]s/wp-json/wp/v2/posts/1"}],
+				"about": [{"href": "%[1]s/wp-json/custom/v1/about"}],
+				"curies": [{"name": "wp", "href": "https://api.w.org/{rel}", "templated": true}]
+			}}`, base)
+		case "/wp-json/wp/v2/users/2":
+			w.Write([]byte(`{"id": 2, "name": "Jane"}`))
+		case "/wp-json/custom/v1/about":
+			w.Write([]byte(`{"answer": 42}`))
+		default:
+			t.Errorf("Unexpected request %v", r.URL)
+		}
+	})
+
+	post, _, err := wp.Posts.Get(ctx, 1, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	links := post.Links
+	if !links.Get(wordpress.LinkSelf).Allows("patch") || links.Get(wordpress.LinkAuthor).Allows("DELETE") {
+		t.Errorf("Unexpected target hints %+v", links.Get(wordpress.LinkSelf).TargetHints)
+	}
+	if links.Get("https://api.w.org/term") != links.Get(wordpress.LinkTerm) || links.Term("post_tag") == nil {
+		t.Errorf("Expected curie and taxonomy lookup to find wp:term links")
+	}
+	if got := links.Get(wordpress.LinkVersionHistory).Count; got != 3 {
+		t.Errorf("Expected 3 revisions, got %d", got)
+	}
+	if got := links.Embeddable(); !reflect.DeepEqual(got, []string{"author", "wp:term"}) {
+		t.Errorf("Unexpected embeddable rels %v", got)
+	}
+	if got := links.Actions(); !reflect.DeepEqual(got, []string{"publish", "sticky"}) || !links.Can("publish") || links.Can("unfiltered-html") {
+		t.Errorf("Unexpected actions %v", got)
+	}
+
+	var author wordpress.User
+	if _, err := wp.Follow(ctx, links.Get(wordpress.LinkAuthor), &author); err != nil || author.Name != "Jane" {
+		t.Errorf("Unexpected author %+v (%v)", author, err)
+	}
+	var about struct{ Answer int }
+	if _, err := wp.Follow(ctx, links.Get(wordpress.LinkAbout), &about); err != nil || about.Answer != 42 {
+		t.Errorf("Unexpected custom resource %+v (%v)", about, err)
+	}
+	if _, err := wp.Follow(ctx, links.Get(wordpress.LinkCuries), &about); err != wordpress.ErrTemplatedLink {
+		t.Errorf("Expected ErrTemplatedLink, got %v", err)
+	}
+	if _, err := wp.Follow(ctx, links.Get("missing"), &about); err != wordpress.ErrNoLink {
+		t.Errorf("Expected ErrNoLink, got %v", err)
+	}
+}
+
+func TestLinks_NotSent(t *testing.T) {
+	var body string
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+		}
+		w.Write([]byte(`{"id":1,"slug":"hello","_links":{"self":[{"href":"http://example.com/wp-json/wp/v2/posts/1"}]}}`))
+	})
+
+	post, _, err := wp.Posts.Get(ctx, 1, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if post.Links.Get(wordpress.LinkSelf) == nil || post.Extra.Has("_links") {
+		t.Errorf("Expected _links to be decoded into Links only, got %v and %v", post.Links, post.Extra)
+	}
+
+	wp.SendExtraFields = true
+	if _, _, err := wp.Posts.Update(ctx, 1, post); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if strings.Contains(body, "_links") {
+		t.Errorf("Expected no _links in update, got %v", body)
+	}
+}
@@ -88,6 +88,16 @@ type TagListOptions struct {
 	ListOptions
 }
 
+// TermListOptions are options that can be passed to TaxonomyService.List().
+type TermListOptions struct {
+	HideEmpty bool     `url:"hide_empty,omitempty"`    // Whether to hide terms not assigned to any posts.
+	Parent    *int     `url:"parent,omitempty"`        // Limit result set to terms assigned to a specific parent, 0 for top-level terms. Hierarchical taxonomies only.
+	Post      int      `url:"post,omitempty"`          // Limit result set to terms assigned to a specific post.
+	Slug      []string `url:"slug,omitempty,brackets"` // Limit result set to terms with one or more specific slugs.
+
+	ListOptions
+}
+
 // UserListOptions are options that can be passed to List().
 type UserListOptions struct {
 	Roles []string `url:"roles,omitempty,brackets"` // Limit result set to users matching at least one specific role provided. Accepts csv list or single role.
@@ -3,6 +3,7 @@ package wordpress
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // MediaDetailsSizesItem provides details for a single media item's size.
@@ -16,11 +17,11 @@ type MediaDetailsSizesItem struct {
 
 // MediaDetailsSizes provides different sizes of the same media item.
 type MediaDetailsSizes struct {
-	Thumbnail MediaDetailsSizesItem `json:"thumbnail,omitempty"`
-	Medium    MediaDetailsSizesItem `json:"medium,omitempty"`
-	Large     MediaDetailsSizesItem `json:"large,omitempty"`
-	SiteLogo  MediaDetailsSizesItem `json:"site-logo,omitempty"`
-	Full      MediaDetailsSizesItem `json:"full,omitempty"`
+	Thumbnail MediaDetailsSizesItem `json:"thumbnail,omitempty,omitzero"`
+	Medium    MediaDetailsSizesItem `json:"medium,omitempty,omitzero"`
+	Large     MediaDetailsSizesItem `json:"large,omitempty,omitzero"`
+	SiteLogo  MediaDetailsSizesItem `json:"site-logo,omitempty,omitzero"`
+	Full      MediaDetailsSizesItem `json:"full,omitempty,omitzero"`
 }
 
 // MediaDetails describes specific details about media.
@@ -30,7 +31,7 @@ type MediaDetails struct {
 	Width     int                    `json:"width,omitempty"`
 	Height    int                    `json:"height,omitempty"`
 	File      string                 `json:"file,omitempty"`
-	Sizes     MediaDetailsSizes      `json:"sizes,omitempty"`
+	Sizes     MediaDetailsSizes      `json:"sizes,omitempty,omitzero"`
 	ImageMeta map[string]interface{} `json:"image_meta,omitempty"`
 }
 
@@ -43,28 +44,76 @@ type MediaUploadOptions struct {
 
 // Media represents a WordPress post media.
 type Media struct {
+	collection *MediaService
+	tracker
+
 	ID           int            `json:"id,omitempty"`
-	Date         Time           `json:"date,omitempty"`
-	DateGMT      TimeGMT        `json:"date_gmt,omitempty"`
-	GUID         RenderedString `json:"guid,omitempty"`
+	Date         Time           `json:"date,omitempty,omitzero"`
+	DateGMT      TimeGMT        `json:"date_gmt,omitempty,omitzero"`
+	GUID         RenderedString `json:"guid,omitempty,omitzero"`
 	Link         string         `json:"link,omitempty"`
-	Modified     Time           `json:"modified,omitempty"`
-	ModifiedGMT  TimeGMT        `json:"modified_gmt,omitempty"`
+	Modified     Time           `json:"modified,omitempty,omitzero"`
+	ModifiedGMT  TimeGMT        `json:"modified_gmt,omitempty,omitzero"`
 	Password     string         `json:"password,omitempty"`
 	Slug         string         `json:"slug,omitempty"`
 	Status       string         `json:"status,omitempty"`
 	Type         string         `json:"type,omitempty"`
-	Title        RenderedString `json:"title,omitempty"`
+	Title        RenderedString `json:"title,omitempty,omitzero"`
 	Author       int            `json:"author,omitempty"`
 	MediaStatus  string         `json:"media_status,omitempty"`
 	PingStatus   string         `json:"ping_status,omitempty"`
 	AltText      string         `json:"alt_text,omitempty"`
-	Caption      RenderedString `json:"caption,omitempty"`
-	Description  RenderedString `json:"description,omitempty"`
+	Caption      RenderedString `json:"caption,omitempty,omitzero"`
+	Description  RenderedString `json:"description,omitempty,omitzero"`
 	MediaType    string         `json:"media_type,omitempty"`
-	MediaDetails MediaDetails   `json:"media_details,omitempty"`
+	MediaDetails MediaDetails   `json:"media_details,omitempty,omitzero"`
 	Post         int            `json:"post,omitempty"`
 	SourceURL    string         `json:"source_url,omitempty"`
+
+	Embedded *Embedded `json:"-"` // Decoded from _embedded when requested with Embed, never sent.
+	Links    Links     `json:"-"` // Decoded from _links, never sent.
+
+	// Extra holds the fields without a struct field, e.g. added by plugins.
+	Extra ExtraFields `json:"-"`
+}
+
+func (entity *Media) setService(c *MediaService) {
+	entity.collection = c
+}
+
+func (entity *Media) service() entityService[Media] {
+	if entity.collection == nil {
+		return nil
+	}
+	return entity.collection
+}
+
+// IsDirty reports whether the media item has changed since it was fetched.
+func (entity *Media) IsDirty() bool {
+	return len(entityChanges(entity)) > 0
+}
+
+// Changed returns the sorted JSON names of the fields changed since the media item was fetched.
+func (entity *Media) Changed() []string {
+	return changedFields(entityChanges(entity))
+}
+
+// Save updates the media item with the fields changed since it was fetched, and
+// refreshes it from the response. It sends no request if nothing has changed.
+func (entity *Media) Save(ctx context.Context) (*Response, error) {
+	return saveEntity(ctx, entity, entity.ID)
+}
+
+// Delete moves the media item to the trash, or deletes it permanently if force
+// is set, and refreshes it from the response. Media can only be trashed if
+// MEDIA_TRASH is enabled.
+func (entity *Media) Delete(ctx context.Context, force bool) (*Response, error) {
+	return deleteEntity(ctx, entity, entity.ID, deleteParams(force))
+}
+
+// Reload fetches the media item again, discarding unsaved changes.
+func (entity *Media) Reload(ctx context.Context) (*Response, error) {
+	return reloadEntity(ctx, entity, entity.ID)
 }
 
 // MediaService provides access to the media related functions in the WordPress REST API.
@@ -87,13 +136,35 @@ func (c *MediaService) List(ctx context.Context, opts *MediaListOptions) ([]*Med
 	if err != nil {
 		return nil, resp, err
 	}
+
+	// set collection object to save, delete and reload the entity
+	for _, entity := range media {
+		entity.setService(c)
+	}
+
 	return media, resp, nil
 }
 
+// All returns an iterator over all media items matching opts, fetching one page after another
+// as the iteration advances.
+func (c *MediaService) All(ctx context.Context, opts *MediaListOptions) iter.Seq2[*Media, error] {
+	return allPages(ctx, opts, c.List)
+}
+
+// ListParallel returns all media items matching opts. The remaining pages are fetched concurrently
+// once the first page has reported the total number of pages.
+func (c *MediaService) ListParallel(ctx context.Context, opts *MediaListOptions, popts *ParallelOptions) ([]*Media, *Response, error) {
+	return listParallel(ctx, opts, c.List, popts)
+}
+
 // Create creates a new media.
 func (c *MediaService) Create(ctx context.Context, options *MediaUploadOptions) (*Media, *Response, error) {
 	var created Media
 	resp, err := c.Client.PostData(ctx, "media", options.Data, options.ContentType, options.Filename, &created)
+
+	// set collection object to save, delete and reload the entity
+	created.setService(c)
+
 	return &created, resp, err
 }
 
@@ -102,13 +173,38 @@ func (c *MediaService) Get(ctx context.Context, id int, params interface{}) (*Me
 	var entity Media
 	entityURL := fmt.Sprintf("media/%v", id)
 	resp, err := c.Client.Get(ctx, entityURL, params, &entity)
+
+	// set collection object to save, delete and reload the entity
+	entity.setService(c)
+
 	return &entity, resp, err
 }
 
+// Update updates a single media item with the given id, e.g. its title or alt text.
+func (c *MediaService) Update(ctx context.Context, id int, media *Media) (*Media, *Response, error) {
+	return c.update(ctx, id, media)
+}
+
+// update updates the media with the given id with body, e.g. a Media or only its changed fields.
+func (c *MediaService) update(ctx context.Context, id int, body interface{}) (*Media, *Response, error) {
+	var updated Media
+	entityURL := fmt.Sprintf("media/%v", id)
+	resp, err := c.Client.Update(ctx, entityURL, body, &updated)
+
+	// set collection object to save, delete and reload the entity
+	updated.setService(c)
+
+	return &updated, resp, err
+}
+
 // Delete removes the media item with the given id.
 func (c *MediaService) Delete(ctx context.Context, id int, params interface{}) (*Media, *Response, error) {
 	var deleted Media
 	entityURL := fmt.Sprintf("media/%v", id)
 	resp, err := c.Client.Delete(ctx, entityURL, params, &deleted)
+
+	// set collection object to save, delete and reload the entity
+	deleted.setService(c)
+
 	return &deleted, resp, err
 }
@@ -0,0 +1,326 @@
+package wordpress
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// ErrUnknownMetaKey is returned from MetaSchema.Validate for meta keys the site has not registered.
+var ErrUnknownMetaKey = errors.New("unknown meta key")
+
+// Meta holds the meta fields of an entity, i.e. the keys registered with
+// show_in_rest. Meta remembers the values it was decoded with and only
+// encodes the keys changed since, so that updating an entity sends only the
+// changed meta keys. Meta with no changed keys is omitted from requests.
+//
+// Copies of a Meta share their values; use Clone for an independent copy.
+type Meta struct {
+	values   map[string]json.RawMessage
+	original map[string]json.RawMessage
+}
+
+// Keys returns the sorted keys of all meta fields.
+func (m *Meta) Keys() []string {
+	keys := make([]string, 0, len(m.values))
+	for key := range m.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Has reports whether the meta field with the given key is present and not null.
+func (m *Meta) Has(key string) bool {
+	raw, ok := m.values[key]
+	return ok && !isJSONNull(raw)
+}
+
+// Raw returns the JSON value of the meta field with the given key, or nil.
+func (m *Meta) Raw(key string) json.RawMessage {
+	return m.values[key]
+}
+
+// Decode decodes the meta field with the given key into v, e.g. an array or
+// object meta field into a slice or struct. It leaves v untouched if the key is missing.
+func (m *Meta) Decode(key string, v interface{}) error {
+	raw, ok := m.values[key]
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// String returns the string meta field with the given key. ok is false if
+// the key is missing or not a string.
+func (m *Meta) String(key string) (value string, ok bool) {
+	return metaValue[string](m, key)
+}
+
+// Int returns the integer meta field with the given key. ok is false if the
+// key is missing or not an integer.
+func (m *Meta) Int(key string) (value int64, ok bool) {
+	return metaValue[int64](m, key)
+}
+
+// Float returns the number meta field with the given key. ok is false if the
+// key is missing or not a number.
+func (m *Meta) Float(key string) (value float64, ok bool) {
+	return metaValue[float64](m, key)
+}
+
+// Bool returns the boolean meta field with the given key. ok is false if the
+// key is missing or not a boolean.
+func (m *Meta) Bool(key string) (value bool, ok bool) {
+	return metaValue[bool](m, key)
+}
+
+// Strings returns the array of strings meta field with the given key. ok is
+// false if the key is missing or not an array of strings.
+func (m *Meta) Strings(key string) (value []string, ok bool) {
+	return metaValue[[]string](m, key)
+}
+
+func metaValue[T any](m *Meta, key string) (T, bool) {
+	var value T
+	raw, ok := m.values[key]
+	if !ok || isJSONNull(raw) {
+		return value, false
+	}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return value, false
+	}
+	return value, true
+}
+
+// Set sets the meta field with the given key to the JSON encoding of value,
+// which may be any string, number, boolean, slice, map or struct.
+func (m *Meta) Set(key string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.setRaw(key, raw)
+	return nil
+}
+
+// SetString sets the meta field with the given key to a string.
+func (m *Meta) SetString(key string, value string) {
+	m.Set(key, value) // nolint: errcheck
+}
+
+// SetInt sets the meta field with the given key to an integer.
+func (m *Meta) SetInt(key string, value int64) {
+	m.Set(key, value) // nolint: errcheck
+}
+
+// SetFloat sets the meta field with the given key to a number.
+func (m *Meta) SetFloat(key string, value float64) {
+	m.Set(key, value) // nolint: errcheck
+}
+
+// SetBool sets the meta field with the given key to a boolean.
+func (m *Meta) SetBool(key string, value bool) {
+	m.Set(key, value) // nolint: errcheck
+}
+
+// Delete sets the meta field with the given key to null, which makes WordPress delete it.
+func (m *Meta) Delete(key string) {
+	m.setRaw(key, json.RawMessage("null"))
+}
+
+func (m *Meta) setRaw(key string, raw json.RawMessage) {
+	if m.values == nil {
+		m.values = make(map[string]json.RawMessage)
+	}
+	m.values[key] = raw
+}
+
+// Changed returns the sorted keys changed since the meta fields were decoded.
+func (m Meta) Changed() []string {
+	var keys []string
+	for key, raw := range m.values {
+		if original, ok := m.original[key]; !ok || !bytes.Equal(original, raw) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsZero reports whether no meta field has changed, so that the meta field is omitted from requests.
+func (m Meta) IsZero() bool {
+	return len(m.Changed()) == 0
+}
+
+// Clone returns an independent copy of m.
+func (m *Meta) Clone() Meta {
+	clone := Meta{}
+	if m.values != nil {
+		clone.values = make(map[string]json.RawMessage, len(m.values))
+		for k, v := range m.values {
+			clone.values[k] = v
+		}
+	}
+	if m.original != nil {
+		clone.original = make(map[string]json.RawMessage, len(m.original))
+		for k, v := range m.original {
+			clone.original[k] = v
+		}
+	}
+	return clone
+}
+
+// MarshalJSON encodes the changed meta fields.
+func (m Meta) MarshalJSON() ([]byte, error) {
+	changed := make(map[string]json.RawMessage)
+	for _, key := range m.Changed() {
+		changed[key] = m.values[key]
+	}
+	return json.Marshal(changed)
+}
+
+// UnmarshalJSON decodes the meta object of a response. WordPress sends an
+// empty array instead of an object if no meta keys are registered.
+func (m *Meta) UnmarshalJSON(b []byte) error {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(b, &values); err != nil {
+		var list []json.RawMessage
+		if json.Unmarshal(b, &list) != nil || len(list) > 0 {
+			return err
+		}
+	}
+	m.values = values
+	m.original = make(map[string]json.RawMessage, len(values))
+	for k, v := range values {
+		m.original[k] = v
+	}
+	return nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
+
+// MetaField describes a registered meta key in the schema of a route.
+type MetaField struct {
+	Description string `json:"description,omitempty"`
+
+	// Type is the JSON schema type of the field, e.g. "string" or "array".
+	// Nullable fields have several types.
+	Type MetaTypes `json:"type"`
+
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+// MetaTypes is the list of JSON schema types of a meta field. WordPress sends
+// either a single type or a list of types.
+type MetaTypes []string
+
+// UnmarshalJSON decodes both a single type and a list of types.
+func (t *MetaTypes) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*t = MetaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// MetaSchema holds the meta keys registered for the entities of a route.
+type MetaSchema struct {
+	Fields map[string]*MetaField
+}
+
+// MetaSchema fetches the meta keys registered for the entities served at the
+// wp/v2 route restBase, e.g. "posts", "users" or a custom post type, from the
+// schema of the route.
+func (c *Client) MetaSchema(ctx context.Context, restBase string) (*MetaSchema, *Response, error) {
+	req, err := c.NewRequest(http.MethodOptions, restBase, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var options struct {
+		Schema struct {
+			Properties struct {
+				Meta struct {
+					Properties map[string]*MetaField `json:"properties"`
+				} `json:"meta"`
+			} `json:"properties"`
+		} `json:"schema"`
+	}
+	resp, err := c.Do(ctx, req, &options)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &MetaSchema{Fields: options.Schema.Properties.Meta.Properties}, resp, nil
+}
+
+// Validate checks the changed keys of m against the schema, and reports
+// unregistered keys (matching ErrUnknownMetaKey) and values not matching the
+// registered type. Deleting a key with a null value is always valid.
+func (s *MetaSchema) Validate(m Meta) error {
+	var errs []error
+	for _, key := range m.Changed() {
+		field, ok := s.Fields[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w %q", ErrUnknownMetaKey, key))
+			continue
+		}
+		raw := m.values[key]
+		if isJSONNull(raw) || len(field.Type) == 0 {
+			continue
+		}
+		if !field.Type.matches(raw) {
+			errs = append(errs, fmt.Errorf("meta key %q is not of type %v", key, field.Type))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// matches reports whether raw is a valid value for one of the types.
+func (t MetaTypes) matches(raw json.RawMessage) bool {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return false
+	}
+	for _, typ := range t {
+		switch v := value.(type) {
+		case string:
+			if typ == "string" {
+				return true
+			}
+		case bool:
+			if typ == "boolean" {
+				return true
+			}
+		case float64:
+			if typ == "number" || (typ == "integer" && v == float64(int64(v))) {
+				return true
+			}
+		case []interface{}:
+			if typ == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if typ == "object" {
+				return true
+			}
+		case nil:
+			if typ == "null" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package wordpress_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestMeta_UpdateSendsChangedKeys(t *testing.T) {
+	var updates []map[string]json.RawMessage
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"id":1,"meta":{"external_id":"abc","sync_count":3,"synced":true,"ratio":0.5,"labels":["a","b"],"source":{"system":"crm"}}}`))
+			return
+		}
+		var body map[string]json.RawMessage
+		json.NewDecoder(r.Body).Decode(&body)
+		updates = append(updates, body)
+		w.Write([]byte(`{"id":1,"meta":[]}`))
+	})
+
+	post, _, err := wp.Posts.Get(ctx, 1, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	meta := &post.Meta
+	if v, ok := meta.String("external_id"); !ok || v != "abc" {
+		t.Errorf("Unexpected external_id %q", v)
+	}
+	if v, ok := meta.Int("sync_count"); !ok || v != 3 {
+		t.Errorf("Unexpected sync_count %d", v)
+	}
+	if v, ok := meta.Bool("synced"); !ok || !v {
+		t.Errorf("Unexpected synced %v", v)
+	}
+	if v, ok := meta.Float("ratio"); !ok || v != 0.5 {
+		t.Errorf("Unexpected ratio %v", v)
+	}
+	if v, ok := meta.Strings("labels"); !ok || len(v) != 2 {
+		t.Errorf("Unexpected labels %v", v)
+	}
+	var source struct{ System string }
+	if err := meta.Decode("source", &source); err != nil || source.System != "crm" {
+		t.Errorf("Unexpected source %+v (%v)", source, err)
+	}
+	if _, ok := meta.Int("external_id"); ok {
+		t.Errorf("Expected type mismatch to report !ok")
+	}
+
+	// unchanged meta is not sent at all
+	if _, _, err := wp.Posts.Update(ctx, 1, post); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if _, ok := updates[0]["meta"]; ok {
+		t.Errorf("Expected no meta in update, got %s", updates[0]["meta"])
+	}
+
+	meta.SetInt("sync_count", 4)
+	meta.SetString("external_id", "abc")
+	meta.Delete("ratio")
+	if _, _, err := wp.Posts.Update(ctx, 1, post); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if got := string(updates[1]["meta"]); got != `{"ratio":null,"sync_count":4}` {
+		t.Errorf("Expected only changed keys, got %s", got)
+	}
+}
+
+func TestMeta_SchemaValidation(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "OPTIONS" || r.URL.Path != "/wp-json/wp/v2/users" {
+			t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+		}
+		w.Write([]byte(`{"schema":{"properties":{"meta":{"type":"object","properties":{
+			"external_id":{"type":"string"},
+			"score":{"type":["integer","null"]}
+		}}}}}`))
+	})
+
+	schema, _, err := wp.MetaSchema(ctx, "users")
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+
+	var user wordpress.User
+	user.Meta.SetString("external_id", "abc")
+	user.Meta.SetInt("score", 7)
+	if err := schema.Validate(user.Meta); err != nil {
+		t.Errorf("Expected valid meta, got %v", err)
+	}
+
+	user.Meta.SetFloat("score", 7.5)
+	user.Meta.SetBool("unregistered", true)
+	err = schema.Validate(user.Meta)
+	if !errors.Is(err, wordpress.ErrUnknownMetaKey) {
+		t.Errorf("Expected ErrUnknownMetaKey, got %v", err)
+	}
+	if err == nil || len(err.(interface{ Unwrap() []error }).Unwrap()) != 2 {
+		t.Errorf("Expected type and key errors, got %v", err)
+	}
+}
+
+func TestMeta_EmptyArray(t *testing.T) {
+	var category wordpress.Category
+	if err := json.Unmarshal([]byte(`{"id":1,"meta":[]}`), &category); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if len(category.Meta.Keys()) != 0 || !category.Meta.IsZero() {
+		t.Errorf("Expected empty meta, got %v", category.Meta.Keys())
+	}
+}
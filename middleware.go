@@ -0,0 +1,63 @@
+package wordpress
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Handler sends an API request and decodes the response into v, like Client.Do.
+type Handler func(ctx context.Context, req *http.Request, v interface{}) (*Response, error)
+
+// Middleware wraps a Handler. A middleware can modify the request before
+// calling next, inspect the decoded response and error afterwards, or answer
+// the request itself without calling next.
+type Middleware func(next Handler) Handler
+
+// Use appends middlewares to the chain run around every request of the client.
+// The first middleware registered is the outermost one. Use must not be called
+// concurrently with requests.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// LoggingMiddleware logs the method, URL, status and duration of every
+// request to logger, or to the standard logger if logger is nil. Passwords in
+// the URL are redacted.
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.New(log.Writer(), "", log.LstdFlags)
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req, v)
+
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			u := *req.URL
+			if err != nil {
+				logger.Printf("[go-wordpress] %v %v: %d in %v: %v", req.Method, sanitizeURL(&u), status, time.Since(start), err)
+			} else {
+				logger.Printf("[go-wordpress] %v %v: %d in %v", req.Method, sanitizeURL(&u), status, time.Since(start))
+			}
+			return resp, err
+		}
+	}
+}
+
+// HeaderMiddleware sets the given headers on every request, replacing any
+// existing values.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+			for k, vs := range header {
+				req.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), vs...)
+			}
+			return next(ctx, req, v)
+		}
+	}
+}
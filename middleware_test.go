@@ -0,0 +1,90 @@
+package wordpress_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestMiddleware_OrderAndHeaders(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Team") != "editorial" {
+			t.Errorf("Expected injected header, got %q", r.Header.Get("X-Team"))
+		}
+		w.Write([]byte(`{"id":1}`))
+	})
+
+	var calls []string
+	trace := func(name string) wordpress.Middleware {
+		return func(next wordpress.Handler) wordpress.Handler {
+			return func(ctx context.Context, req *http.Request, v interface{}) (*wordpress.Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next(ctx, req, v)
+				calls = append(calls, name+" after")
+				return resp, err
+			}
+		}
+	}
+	wp.Use(trace("outer"), wordpress.HeaderMiddleware(http.Header{"X-Team": {"editorial"}}))
+	wp.Use(trace("inner"))
+
+	if _, _, err := wp.Posts.Get(ctx, 1, nil); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if strings.Join(calls, ", ") != "outer before, inner before, inner after, outer after" {
+		t.Errorf("Unexpected middleware order: %v", calls)
+	}
+}
+
+func TestMiddleware_ShortCircuitAndRewrite(t *testing.T) {
+	staging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":2,"slug":"from-staging"}`))
+	}))
+	defer staging.Close()
+	stagingURL, _ := url.Parse(staging.URL)
+
+	readOnly := errors.New("read-only mode")
+	wp, _ := wordpress.NewClient("http://production.invalid/", nil)
+	wp.Use(func(next wordpress.Handler) wordpress.Handler {
+		return func(ctx context.Context, req *http.Request, v interface{}) (*wordpress.Response, error) {
+			if req.Method != "GET" {
+				return nil, readOnly
+			}
+			req.URL.Host = stagingURL.Host
+			return next(ctx, req, v)
+		}
+	})
+	ctx := context.Background()
+
+	post, _, err := wp.Posts.Get(ctx, 2, nil)
+	if err != nil || post.Slug != "from-staging" {
+		t.Errorf("Expected post from staging, got %+v (%v)", post, err)
+	}
+	if _, _, err := wp.Posts.Delete(ctx, 2, nil); err != readOnly {
+		t.Errorf("Expected short-circuit error, got %v", err)
+	}
+}
+
+func TestMiddleware_LoggingRedactsPassword(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	})
+	var buf bytes.Buffer
+	wp.Use(wordpress.LoggingMiddleware(log.New(&buf, "", 0)))
+
+	wp.Comments.List(ctx, &wordpress.CommentListOptions{Password: "secret"})
+	if !strings.Contains(buf.String(), "GET ") || !strings.Contains(buf.String(), ": 200 in ") {
+		t.Errorf("Unexpected log output %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "secret") {
+		t.Errorf("Password should be redacted in %q", buf.String())
+	}
+}
@@ -0,0 +1,338 @@
+package wordpress
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoOAuth1Token is returned from OAuth1Transport if its token store holds no access token.
+var ErrNoOAuth1Token = errors.New("no OAuth1 access token")
+
+// OAuth1Token is an OAuth 1.0a request or access token.
+type OAuth1Token struct {
+	Token       string
+	TokenSecret string
+}
+
+// OAuth1Endpoint contains the URLs of the OAuth 1.0a handshake.
+type OAuth1Endpoint struct {
+	RequestTokenURL string
+	AuthorizeURL    string
+	AccessTokenURL  string
+}
+
+// WordPressOAuth1Endpoint returns the handshake endpoints of the WP REST API
+// OAuth1 plugin installed on the site at siteURL.
+func WordPressOAuth1Endpoint(siteURL string) OAuth1Endpoint {
+	siteURL = strings.TrimSuffix(siteURL, "/")
+	return OAuth1Endpoint{
+		RequestTokenURL: siteURL + "/oauth1/request",
+		AuthorizeURL:    siteURL + "/oauth1/authorize",
+		AccessTokenURL:  siteURL + "/oauth1/access",
+	}
+}
+
+// OAuth1Config describes an OAuth 1.0a consumer (client application) and the
+// endpoints used to obtain access tokens for it.
+type OAuth1Config struct {
+	ConsumerKey    string
+	ConsumerSecret string
+
+	// CallbackURL is where the user is redirected after authorizing the
+	// consumer. Defaults to "oob" (out of band), in which case the verifier is
+	// shown to the user.
+	CallbackURL string
+
+	Endpoint OAuth1Endpoint
+
+	// Transport is the underlying HTTP transport used for the handshake.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+}
+
+// RequestToken obtains a temporary request token, the first step of the handshake.
+func (c *OAuth1Config) RequestToken(ctx context.Context) (*OAuth1Token, error) {
+	callback := c.CallbackURL
+	if callback == "" {
+		callback = "oob"
+	}
+	values, err := c.handshake(ctx, c.Endpoint.RequestTokenURL, nil, map[string]string{"oauth_callback": callback})
+	if err != nil {
+		return nil, err
+	}
+	if values.Get("oauth_callback_confirmed") != "true" {
+		return nil, errors.New("oauth_callback_confirmed was not true")
+	}
+	return &OAuth1Token{Token: values.Get("oauth_token"), TokenSecret: values.Get("oauth_token_secret")}, nil
+}
+
+// AuthorizationURL returns the URL the user must visit to authorize the request token.
+func (c *OAuth1Config) AuthorizationURL(requestToken *OAuth1Token) (*url.URL, error) {
+	u, err := url.Parse(c.Endpoint.AuthorizeURL)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set("oauth_token", requestToken.Token)
+	u.RawQuery = q.Encode()
+	return u, nil
+}
+
+// AccessToken exchanges an authorized request token and the verifier shown to
+// (or redirected with) the user for an access token, the last step of the handshake.
+func (c *OAuth1Config) AccessToken(ctx context.Context, requestToken *OAuth1Token, verifier string) (*OAuth1Token, error) {
+	values, err := c.handshake(ctx, c.Endpoint.AccessTokenURL, requestToken, map[string]string{"oauth_verifier": verifier})
+	if err != nil {
+		return nil, err
+	}
+	return &OAuth1Token{Token: values.Get("oauth_token"), TokenSecret: values.Get("oauth_token_secret")}, nil
+}
+
+// handshake posts a signed request to a handshake endpoint and decodes the form encoded response.
+func (c *OAuth1Config) handshake(ctx context.Context, endpoint string, token *OAuth1Token, extra map[string]string) (url.Values, error) {
+	req, err := http.NewRequest("POST", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.sign(req, token, extra); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%v %v: %d %s", req.Method, sanitizeURL(req.URL), resp.StatusCode, bytes.TrimSpace(data))
+	}
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if values.Get("oauth_token") == "" {
+		return nil, fmt.Errorf("%v %v: response has no oauth_token", req.Method, sanitizeURL(req.URL))
+	}
+	return values, nil
+}
+
+// sign adds an HMAC-SHA1 signed OAuth Authorization header to req. The query
+// parameters and form encoded bodies are part of the signature; other bodies,
+// such as JSON or the multipart uploads of PostData, are not (RFC 5849, 3.4.1.3).
+func (c *OAuth1Config) sign(req *http.Request, token *OAuth1Token, extra map[string]string) error {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     c.ConsumerKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	tokenSecret := ""
+	if token != nil {
+		oauthParams["oauth_token"] = token.Token
+		tokenSecret = token.TokenSecret
+	}
+	for k, v := range extra {
+		oauthParams[k] = v
+	}
+
+	params := url.Values{}
+	for k, v := range req.URL.Query() {
+		params[k] = v
+	}
+	if req.Body != nil && req.Body != http.NoBody &&
+		strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, err := readForm(req)
+		if err != nil {
+			return err
+		}
+		for k, v := range form {
+			params[k] = append(params[k], v...)
+		}
+	}
+	for k, v := range oauthParams {
+		params.Set(k, v)
+	}
+
+	key := oauthEscape(c.ConsumerSecret) + "&" + oauthEscape(tokenSecret)
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(oauthBaseString(req.Method, req.URL, params)))
+	oauthParams["oauth_signature"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	names := make([]string, 0, len(oauthParams))
+	for k := range oauthParams {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	header := make([]string, len(names))
+	for i, k := range names {
+		header[i] = fmt.Sprintf(`%s="%s"`, oauthEscape(k), oauthEscape(oauthParams[k]))
+	}
+	req.Header.Set("Authorization", "OAuth "+strings.Join(header, ", "))
+	return nil
+}
+
+func (c *OAuth1Config) transport() http.RoundTripper {
+	if c.Transport != nil {
+		return c.Transport
+	}
+	return http.DefaultTransport
+}
+
+// readForm parses a form encoded request body and restores it.
+func readForm(req *http.Request) (url.Values, error) {
+	data, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return url.ParseQuery(string(data))
+}
+
+// oauthBaseString builds the signature base string of RFC 5849, 3.4.1.
+func oauthBaseString(method string, u *url.URL, params url.Values) string {
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Host)
+	if (scheme == "http" && strings.HasSuffix(host, ":80")) || (scheme == "https" && strings.HasSuffix(host, ":443")) {
+		host = host[:strings.LastIndex(host, ":")]
+	}
+	baseURI := scheme + "://" + host + u.EscapedPath()
+
+	pairs := make([]string, 0, len(params))
+	for k, vs := range params {
+		for _, v := range vs {
+			pairs = append(pairs, oauthEscape(k)+"="+oauthEscape(v))
+		}
+	}
+	sort.Strings(pairs)
+
+	return strings.ToUpper(method) + "&" + oauthEscape(baseURI) + "&" + oauthEscape(strings.Join(pairs, "&"))
+}
+
+// oauthEscape percent-encodes s as required by RFC 5849, 3.6: everything but
+// the unreserved characters of RFC 3986.
+func oauthEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// OAuth1TokenStore stores the access token used by OAuth1Transport, e.g. in a
+// database or a keychain. It must be safe for concurrent use.
+type OAuth1TokenStore interface {
+	// Token returns the access token, or nil if there is none.
+	Token(ctx context.Context) (*OAuth1Token, error)
+	SaveToken(ctx context.Context, token *OAuth1Token) error
+}
+
+// MemoryOAuth1TokenStore is an OAuth1TokenStore keeping the token in memory.
+type MemoryOAuth1TokenStore struct {
+	mu    sync.RWMutex
+	token *OAuth1Token
+}
+
+// NewMemoryOAuth1TokenStore returns a MemoryOAuth1TokenStore holding token, which may be nil.
+func NewMemoryOAuth1TokenStore(token *OAuth1Token) *MemoryOAuth1TokenStore {
+	return &MemoryOAuth1TokenStore{token: token}
+}
+
+// Token implements the OAuth1TokenStore interface.
+func (s *MemoryOAuth1TokenStore) Token(ctx context.Context) (*OAuth1Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.token, nil
+}
+
+// SaveToken implements the OAuth1TokenStore interface.
+func (s *MemoryOAuth1TokenStore) SaveToken(ctx context.Context, token *OAuth1Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	return nil
+}
+
+// OAuth1Transport is an http.RoundTripper that signs all requests with
+// OAuth 1.0a (HMAC-SHA1) using the consumer of Config and the access token
+// held by Store, as expected by the WP REST API OAuth1 plugin.
+type OAuth1Transport struct {
+	Config *OAuth1Config
+	Store  OAuth1TokenStore
+
+	// Transport is the underlying HTTP transport to use when making requests.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *OAuth1Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Store.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, ErrNoOAuth1Token
+	}
+
+	// sign a copy; the original request must not be modified
+	req2 := withHeader(req, "Authorization", "")
+	if err := t.Config.sign(req2, token, nil); err != nil {
+		return nil, err
+	}
+	return t.transport().RoundTrip(req2)
+}
+
+// Client returns an *http.Client that makes requests that are signed with OAuth 1.0a.
+func (t *OAuth1Transport) Client() *http.Client {
+	return &http.Client{Jar: nil, Transport: t}
+}
+
+func (t *OAuth1Transport) cacheIdentity() string {
+	var consumerKey string
+	if t.Config != nil {
+		consumerKey = t.Config.ConsumerKey
+	}
+	// the token is identified by the store holding it
+	return fmt.Sprintf("oauth1 %v %p", consumerKey, t.Store)
+}
+
+func (t *OAuth1Transport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
@@ -0,0 +1,132 @@
+package wordpress_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+// verifyOAuth1 checks the HMAC-SHA1 signature of r as a provider would, and
+// returns the oauth parameters of the Authorization header.
+func verifyOAuth1(t *testing.T, r *http.Request, consumerSecret, tokenSecret string) map[string]string {
+	escape := func(s string) string {
+		return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+	}
+
+	oauth := map[string]string{}
+	header := strings.TrimPrefix(r.Header.Get("Authorization"), "OAuth ")
+	for _, part := range strings.Split(header, ", ") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			t.Errorf("Malformed Authorization header %q", header)
+			return oauth
+		}
+		k, _ := url.QueryUnescape(kv[0])
+		v, _ := url.QueryUnescape(strings.Trim(kv[1], `"`))
+		oauth[k] = v
+	}
+
+	var pairs []string
+	for k, vs := range r.URL.Query() {
+		for _, v := range vs {
+			pairs = append(pairs, escape(k)+"="+escape(v))
+		}
+	}
+	for k, v := range oauth {
+		if k != "oauth_signature" {
+			pairs = append(pairs, escape(k)+"="+escape(v))
+		}
+	}
+	sort.Strings(pairs)
+	base := r.Method + "&" + escape("http://"+r.Host+r.URL.EscapedPath()) + "&" + escape(strings.Join(pairs, "&"))
+
+	mac := hmac.New(sha1.New, []byte(escape(consumerSecret)+"&"+escape(tokenSecret)))
+	mac.Write([]byte(base))
+	if expected := base64.StdEncoding.EncodeToString(mac.Sum(nil)); oauth["oauth_signature"] != expected {
+		t.Errorf("Invalid signature for %v %v: got %v, expected %v", r.Method, r.URL, oauth["oauth_signature"], expected)
+	}
+	return oauth
+}
+
+func TestOAuth1_HandshakeAndSignedRequests(t *testing.T) {
+	const consumerSecret = "consumer secret"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth1/request":
+			oauth := verifyOAuth1(t, r, consumerSecret, "")
+			if oauth["oauth_callback"] != "oob" {
+				t.Errorf("Expected oob callback, got %q", oauth["oauth_callback"])
+			}
+			w.Write([]byte("oauth_token=request-token&oauth_token_secret=request%20secret&oauth_callback_confirmed=true"))
+		case "/oauth1/access":
+			oauth := verifyOAuth1(t, r, consumerSecret, "request secret")
+			if oauth["oauth_token"] != "request-token" || oauth["oauth_verifier"] != "verifier" {
+				t.Errorf("Unexpected access token request %v", oauth)
+			}
+			w.Write([]byte("oauth_token=access-token&oauth_token_secret=access~secret"))
+		default:
+			oauth := verifyOAuth1(t, r, consumerSecret, "access~secret")
+			if oauth["oauth_token"] != "access-token" {
+				t.Errorf("Expected access token, got %v", oauth["oauth_token"])
+			}
+			if r.Method == "POST" {
+				w.Write([]byte(`{"id":5}`))
+				return
+			}
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	config := &wordpress.OAuth1Config{
+		ConsumerKey:    "consumer-key",
+		ConsumerSecret: consumerSecret,
+		Endpoint:       wordpress.WordPressOAuth1Endpoint(server.URL),
+	}
+	requestToken, err := config.RequestToken(ctx)
+	if err != nil {
+		t.Fatalf("RequestToken should not return error: %v", err)
+	}
+	authURL, _ := config.AuthorizationURL(requestToken)
+	if authURL.Query().Get("oauth_token") != "request-token" {
+		t.Errorf("Unexpected authorization URL %v", authURL)
+	}
+	accessToken, err := config.AccessToken(ctx, requestToken, "verifier")
+	if err != nil {
+		t.Fatalf("AccessToken should not return error: %v", err)
+	}
+
+	tp := &wordpress.OAuth1Transport{Config: config, Store: wordpress.NewMemoryOAuth1TokenStore(accessToken)}
+	for _, nonPretty := range []bool{false, true} {
+		wp, _ := wordpress.NewClient(server.URL, tp.Client())
+		wp.NonPrettyPermalinks = nonPretty
+
+		opts := &wordpress.PostListOptions{Tags: []int{1, 2}, ListOptions: wordpress.ListOptions{Search: "hello world & more", PerPage: 5}}
+		if _, _, err := wp.Posts.List(ctx, opts); err != nil {
+			t.Errorf("List should not return error: %v", err)
+		}
+		upload := &wordpress.MediaUploadOptions{Filename: "a b.txt", ContentType: "text/plain", Data: []byte("data")}
+		if _, _, err := wp.Media.Create(ctx, upload); err != nil {
+			t.Errorf("Upload should not return error: %v", err)
+		}
+	}
+}
+
+func TestOAuth1_NoToken(t *testing.T) {
+	tp := &wordpress.OAuth1Transport{Config: &wordpress.OAuth1Config{}, Store: wordpress.NewMemoryOAuth1TokenStore(nil)}
+	wp, _ := wordpress.NewClient("http://127.0.0.1:1/", tp.Client())
+	if _, _, err := wp.Posts.List(context.Background(), nil); !errors.Is(err, wordpress.ErrNoOAuth1Token) {
+		t.Errorf("Expected ErrNoOAuth1Token, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package wordpress
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// RequestInfo describes a request passed to an Observer.
+type RequestInfo struct {
+	Method string
+
+	// Route is the route template of the request, with IDs replaced by
+	// placeholders and the wp/v2 namespace omitted, e.g. "posts/{id}" or
+	// "posts/{id}/revisions/{id}". It is suitable as a metric label.
+	Route string
+
+	// URL is the request URL with passwords redacted.
+	URL *url.URL
+}
+
+// RequestStats describes a completed request passed to an Observer.
+type RequestStats struct {
+	RequestInfo
+
+	StatusCode int           // Zero if no response was received.
+	Bytes      int64         // Number of response body bytes read.
+	Duration   time.Duration // Total duration, including retries and limiter waits.
+	Retries    int           // Number of attempts after the first one.
+	FromCache  bool          // Whether the response was served from the client Cache.
+	ErrorClass string        // ErrorClass of Err; empty on success.
+	Err        error
+}
+
+// Observer is notified about every request sent with Client.Do, e.g. to record
+// metrics or tracing spans. The context returned by RequestStarted is used
+// for the request and passed to RequestFinished, so that a span can be
+// carried from one to the other. Observers must be safe for concurrent use.
+type Observer interface {
+	RequestStarted(ctx context.Context, info *RequestInfo) context.Context
+	RequestFinished(ctx context.Context, stats *RequestStats)
+}
+
+// ErrorClass returns a short, stable name for the class of err, suitable as a
+// metric label: the snake-cased Err* class of API errors (e.g. "not_found",
+// "rate_limited"), "unexpected_response" for non-JSON error pages,
+// "canceled", "timeout", or "transport" for other errors. It returns an empty
+// string for a nil error.
+func ErrorClass(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		if class := apiErr.Class(); class != nil {
+			return strings.Replace(class.Error(), " ", "_", -1)
+		}
+		return "api_error"
+	}
+	var unexpectedErr *UnexpectedResponseError
+	if errors.As(err, &unexpectedErr) {
+		return "unexpected_response"
+	}
+	if errors.Is(err, context.Canceled) {
+		return "canceled"
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	return "transport"
+}
+
+var (
+	routeIDPattern   = regexp.MustCompile(`^[0-9]+$`)
+	routeUUIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// routeTemplate returns the route template of a REST API URL, see RequestInfo.Route.
+func routeTemplate(u *url.URL) string {
+	route := strings.TrimPrefix(restRoute(u), apiPathPrefix[1:]+"/")
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		switch {
+		case routeIDPattern.MatchString(segment):
+			segments[i] = "{id}"
+		case routeUUIDPattern.MatchString(segment):
+			segments[i] = "{uuid}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// requestTrace collects per-request numbers from the inner layers of Client.Do.
+type requestTrace struct {
+	attempts int32
+	bytes    int64
+}
+
+type requestTraceKey struct{}
+
+func traceFromContext(ctx context.Context) *requestTrace {
+	trace, _ := ctx.Value(requestTraceKey{}).(*requestTrace)
+	return trace
+}
+
+// countingBody counts the bytes read from a response body.
+type countingBody struct {
+	io.ReadCloser
+	n *int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	atomic.AddInt64(b.n, int64(n))
+	return n, err
+}
+
+// observe runs handler for req, notifying c.Observer before and after.
+func (c *Client) observe(ctx context.Context, req *http.Request, v interface{}, handler Handler) (*Response, error) {
+	u := *req.URL
+	info := &RequestInfo{
+		Method: req.Method,
+		Route:  routeTemplate(req.URL),
+		URL:    sanitizeURL(&u),
+	}
+
+	trace := &requestTrace{}
+	ctx = context.WithValue(ctx, requestTraceKey{}, trace)
+	ctx = c.Observer.RequestStarted(ctx, info)
+
+	start := time.Now()
+	resp, err := handler(ctx, req, v)
+
+	stats := &RequestStats{
+		RequestInfo: *info,
+		Duration:    time.Since(start),
+		Bytes:       atomic.LoadInt64(&trace.bytes),
+		ErrorClass:  ErrorClass(err),
+		Err:         err,
+	}
+	if attempts := int(atomic.LoadInt32(&trace.attempts)); attempts > 1 {
+		stats.Retries = attempts - 1
+	}
+	if resp != nil {
+		stats.StatusCode = resp.StatusCode
+		stats.FromCache = resp.FromCache
+	}
+	c.Observer.RequestFinished(ctx, stats)
+
+	return resp, err
+}
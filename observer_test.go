@@ -0,0 +1,127 @@
+package wordpress_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+type recordingObserver struct {
+	mu       sync.Mutex
+	started  []*wordpress.RequestInfo
+	finished []*wordpress.RequestStats
+}
+
+func (o *recordingObserver) RequestStarted(ctx context.Context, info *wordpress.RequestInfo) context.Context {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.started = append(o.started, info)
+	return ctx
+}
+
+func (o *recordingObserver) RequestFinished(ctx context.Context, stats *wordpress.RequestStats) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.finished = append(o.finished, stats)
+}
+
+func TestObserver_Stats(t *testing.T) {
+	var requests int32
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"code":"unavailable","message":"busy"}`))
+			return
+		}
+		w.Write([]byte(`{"id":7,"slug":"hello"}`))
+	})
+	wp.RetryPolicy = &wordpress.RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	observer := &recordingObserver{}
+	wp.Observer = observer
+
+	if _, _, err := wp.Posts.Get(ctx, 7, nil); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+
+	if len(observer.started) != 1 || len(observer.finished) != 1 {
+		t.Fatalf("Expected one notification each, got %d/%d", len(observer.started), len(observer.finished))
+	}
+	stats := observer.finished[0]
+	if stats.Method != "GET" || stats.Route != "posts/{id}" {
+		t.Errorf("Unexpected method/route %v %v", stats.Method, stats.Route)
+	}
+	if stats.StatusCode != http.StatusOK || stats.Retries != 1 || stats.ErrorClass != "" {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	if stats.Bytes != int64(len(`{"id":7,"slug":"hello"}`)) {
+		t.Errorf("Expected body size to be counted, got %d", stats.Bytes)
+	}
+	if stats.Duration <= 0 {
+		t.Errorf("Expected positive duration, got %v", stats.Duration)
+	}
+}
+
+func TestObserver_ErrorClassAndRedaction(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"rest_post_invalid_id","message":"Invalid post ID."}`))
+	})
+	observer := &recordingObserver{}
+	wp.Observer = observer
+
+	wp.Comments.List(ctx, &wordpress.CommentListOptions{Password: "secret"})
+	wp.Posts.Entity(9).Revisions().Get(ctx, 12, nil)
+
+	if len(observer.finished) != 2 {
+		t.Fatalf("Expected two notifications, got %d", len(observer.finished))
+	}
+	if stats := observer.finished[0]; stats.ErrorClass != "not_found" || strings.Contains(stats.URL.String(), "secret") {
+		t.Errorf("Unexpected stats %+v (%v)", stats, stats.URL)
+	}
+	if route := observer.finished[1].Route; route != "posts/{id}/revisions/{id}" {
+		t.Errorf("Unexpected route template %q", route)
+	}
+}
+
+func TestPrometheusObserver(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"code":"rest_cannot_delete","message":"No."}`))
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	})
+	metrics := wordpress.NewPrometheusObserver("wp")
+	wp.Observer = metrics
+
+	wp.Posts.Get(ctx, 1, nil)
+	wp.Posts.Get(ctx, 2, nil)
+	wp.Posts.Delete(ctx, 1, nil)
+
+	var buf bytes.Buffer
+	if _, err := metrics.WriteTo(&buf); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# TYPE wp_requests_total counter\n",
+		`wp_requests_total{method="GET",route="posts/{id}",status="200",error_class=""} 2` + "\n",
+		`wp_requests_total{method="DELETE",route="posts/{id}",status="403",error_class="forbidden"} 1` + "\n",
+		`wp_request_duration_seconds_bucket{method="GET",route="posts/{id}",le="+Inf"} 2` + "\n",
+		`wp_request_duration_seconds_count{method="GET",route="posts/{id}"} 2` + "\n",
+		`wp_response_bytes_total{method="GET",route="posts/{id}"} 16` + "\n",
+		`wp_requests_in_flight{method="GET",route="posts/{id}"} 0` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in metrics:\n%s", want, out)
+		}
+	}
+}
@@ -3,40 +3,87 @@ package wordpress
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log"
 )
 
 // Page represents a WordPress page.
 type Page struct {
 	collection *PagesService
+	tracker
 
 	ID            int            `json:"id,omitempty"`
-	Date          Time           `json:"date,omitempty"`
-	DateGMT       TimeGMT        `json:"date_gmt,omitempty"`
-	GUID          RenderedString `json:"guid,omitempty"`
+	Date          Time           `json:"date,omitempty,omitzero"`
+	DateGMT       TimeGMT        `json:"date_gmt,omitempty,omitzero"`
+	GUID          RenderedString `json:"guid,omitempty,omitzero"`
 	Link          string         `json:"link,omitempty"`
-	Modified      Time           `json:"modified,omitempty"`
-	ModifiedGMT   TimeGMT        `json:"modified_gmt,omitempty"`
+	Modified      Time           `json:"modified,omitempty,omitzero"`
+	ModifiedGMT   TimeGMT        `json:"modified_gmt,omitempty,omitzero"`
 	Password      string         `json:"password,omitempty"`
 	Slug          string         `json:"slug,omitempty"`
 	Status        string         `json:"status,omitempty"`
 	Type          string         `json:"type,omitempty"`
 	Parent        int            `json:"parent,omitempty"`
-	Title         RenderedString `json:"title,omitempty"`
-	Content       RenderedString `json:"content,omitempty"`
+	Title         RenderedString `json:"title,omitempty,omitzero"`
+	Content       RenderedString `json:"content,omitempty,omitzero"`
 	Author        int            `json:"author,omitempty"`
-	Excerpt       RenderedString `json:"excerpt,omitempty"`
+	Excerpt       RenderedString `json:"excerpt,omitempty,omitzero"`
 	FeaturedImage int            `json:"featured_image,omitempty"`
 	CommentStatus string         `json:"comment_status,omitempty"`
 	PingStatus    string         `json:"ping_status,omitempty"`
 	MenuOrder     int            `json:"menu_order,omitempty"`
 	Template      string         `json:"template,omitempty"`
+	Meta          Meta           `json:"meta,omitzero"` // Meta fields registered with show_in_rest.
+
+	Embedded *Embedded `json:"-"` // Decoded from _embedded when requested with Embed, never sent.
+	Links    Links     `json:"-"` // Decoded from _links, never sent.
+
+	// Extra holds the fields without a struct field, e.g. added by plugins.
+	Extra ExtraFields `json:"-"`
 }
 
 func (entity *Page) setService(c *PagesService) {
 	entity.collection = c
 }
 
+func (entity *Page) service() entityService[Page] {
+	if entity.collection == nil {
+		return nil
+	}
+	return entity.collection
+}
+
+func (entity *Page) base() snapshot {
+	return baseSnapshot[Page](&entity.tracker)
+}
+
+// IsDirty reports whether the page has changed since it was fetched.
+func (entity *Page) IsDirty() bool {
+	return len(entityChanges(entity)) > 0
+}
+
+// Changed returns the sorted JSON names of the fields changed since the page was fetched.
+func (entity *Page) Changed() []string {
+	return changedFields(entityChanges(entity))
+}
+
+// Save updates the page with the fields changed since it was fetched, and
+// refreshes it from the response. It sends no request if nothing has changed.
+func (entity *Page) Save(ctx context.Context) (*Response, error) {
+	return saveEntity(ctx, entity, entity.ID)
+}
+
+// Delete moves the page to the trash, or deletes it permanently if force is
+// set, and refreshes it from the response.
+func (entity *Page) Delete(ctx context.Context, force bool) (*Response, error) {
+	return deleteEntity(ctx, entity, entity.ID, deleteParams(force))
+}
+
+// Reload fetches the page again, discarding unsaved changes.
+func (entity *Page) Reload(ctx context.Context) (*Response, error) {
+	return reloadEntity(ctx, entity, entity.ID)
+}
+
 // Revisions gets the revisions of a single page.
 func (entity *Page) Revisions() *RevisionsService {
 	if entity.collection == nil {
@@ -86,6 +133,18 @@ func (c *PagesService) List(ctx context.Context, opts *PageListOptions) ([]*Page
 	return pages, resp, nil
 }
 
+// All returns an iterator over all pages matching opts, fetching one page after another
+// as the iteration advances.
+func (c *PagesService) All(ctx context.Context, opts *PageListOptions) iter.Seq2[*Page, error] {
+	return allPages(ctx, opts, c.List)
+}
+
+// ListParallel returns all pages matching opts. The remaining pages are fetched concurrently
+// once the first page has reported the total number of pages.
+func (c *PagesService) ListParallel(ctx context.Context, opts *PageListOptions, popts *ParallelOptions) ([]*Page, *Response, error) {
+	return listParallel(ctx, opts, c.List, popts)
+}
+
 // Create creates a new page.
 func (c *PagesService) Create(ctx context.Context, newPage *Page) (*Page, *Response, error) {
 	var created Page
@@ -119,9 +178,26 @@ func (c *PagesService) Entity(id int) *Page {
 
 // Update updates a single page with the given id.
 func (c *PagesService) Update(ctx context.Context, id int, page *Page) (*Page, *Response, error) {
+	return c.update(ctx, id, page)
+}
+
+// update updates the page with the given id with body, e.g. a Page or only its changed fields.
+func (c *PagesService) update(ctx context.Context, id int, body interface{}) (*Page, *Response, error) {
+	var updated Page
+	entityURL := fmt.Sprintf("pages/%v", id)
+	resp, err := c.Client.Update(ctx, entityURL, body, &updated)
+
+	// set collection object for each entity which has sub-collection
+	updated.setService(c)
+
+	return &updated, resp, err
+}
+
+// Patch changes only the fields of the page with the given id which are set in patch.
+func (c *PagesService) Patch(ctx context.Context, id int, patch PagePatch) (*Page, *Response, error) {
 	var updated Page
 	entityURL := fmt.Sprintf("pages/%v", id)
-	resp, err := c.Client.Update(ctx, entityURL, page, &updated)
+	resp, err := c.Client.Patch(ctx, entityURL, patch, &updated)
 
 	// set collection object for each entity which has sub-collection
 	updated.setService(c)
@@ -0,0 +1,86 @@
+package wordpress
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// ErrMaxItemsExceeded is returned from Collect if the iterator yields more than the allowed number of items.
+var ErrMaxItemsExceeded = errors.New("collection has more items than allowed")
+
+// pager is implemented by every *XListOptions through the embedded ListOptions.
+type pager interface {
+	setPage(page int)
+	page() int
+}
+
+func (o *ListOptions) setPage(page int) {
+	o.Page = page
+}
+
+func (o *ListOptions) page() int {
+	return o.Page
+}
+
+// listFunc fetches a single page of a collection.
+type listFunc[T any, P any] func(ctx context.Context, opts P) ([]T, *Response, error)
+
+// allPages returns an iterator over every item of a collection, calling list
+// lazily for one page after another as the consumer advances. Iteration starts
+// at opts.Page (or the first page) and ends after the last page reported by
+// X-WP-TotalPages, on the first error, or as soon as the consumer stops.
+// opts is copied and never modified.
+func allPages[T any, O any, P interface {
+	*O
+	pager
+}](ctx context.Context, opts P, list listFunc[T, P]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var o O
+		if opts != nil {
+			o = *opts
+		}
+		pageOpts := P(&o)
+
+		page := pageOpts.page()
+		if page < 1 {
+			page = 1
+		}
+		for {
+			pageOpts.setPage(page)
+			items, resp, err := list(ctx, pageOpts)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(items) == 0 || resp == nil || resp.NextPage == 0 {
+				return
+			}
+			page = resp.NextPage
+		}
+	}
+}
+
+// Collect drains seq into a slice. If maxItems is positive and seq yields more
+// than maxItems items, iteration is stopped and the first maxItems items are
+// returned together with ErrMaxItemsExceeded. The first error yielded by seq is
+// returned along with the items collected so far.
+func Collect[T any](seq iter.Seq2[T, error], maxItems int) ([]T, error) {
+	items := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		if maxItems > 0 && len(items) == maxItems {
+			return items, ErrMaxItemsExceeded
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
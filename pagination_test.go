@@ -0,0 +1,148 @@
+package wordpress_test
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+// servePages serves totalPages pages of perPage posts with consecutive IDs.
+func servePages(totalPages, perPage int, requests *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		if page > totalPages {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"rest_post_invalid_page_number","message":"The page number requested is larger than the number of pages available.","data":{"status":400}}`))
+			return
+		}
+		w.Header().Set("X-WP-Total", strconv.Itoa(totalPages*perPage))
+		w.Header().Set("X-WP-TotalPages", strconv.Itoa(totalPages))
+		body := "["
+		for i := 0; i < perPage; i++ {
+			if i > 0 {
+				body += ","
+			}
+			body += fmt.Sprintf(`{"id":%d}`, (page-1)*perPage+i+1)
+		}
+		w.Write([]byte(body + "]"))
+	}
+}
+
+func TestPagination_AllWalksEveryPage(t *testing.T) {
+	var requests int32
+	wp, ctx := initStubClient(t, servePages(3, 2, &requests))
+
+	opts := &wordpress.PostListOptions{}
+	var ids []int
+	for post, err := range wp.Posts.All(ctx, opts) {
+		if err != nil {
+			t.Fatalf("Should not return error: %v", err)
+		}
+		ids = append(ids, post.ID)
+	}
+	if len(ids) != 6 || ids[0] != 1 || ids[5] != 6 {
+		t.Errorf("Expected posts 1..6 in order, got %v", ids)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %v", requests)
+	}
+	if opts.Page != 0 {
+		t.Errorf("All should not modify the given options, page is %v", opts.Page)
+	}
+}
+
+func TestPagination_AllStopsWhenConsumerBreaks(t *testing.T) {
+	var requests int32
+	wp, ctx := initStubClient(t, servePages(5, 2, &requests))
+
+	for post, err := range wp.Posts.All(ctx, nil) {
+		if err != nil {
+			t.Fatalf("Should not return error: %v", err)
+		}
+		if post.ID == 3 {
+			break
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %v", requests)
+	}
+}
+
+func TestPagination_CollectMaxItems(t *testing.T) {
+	var requests int32
+	wp, ctx := initStubClient(t, servePages(5, 2, &requests))
+
+	users, err := wordpress.Collect(wp.Users.All(ctx, nil), 3)
+	if err != wordpress.ErrMaxItemsExceeded {
+		t.Errorf("Expected ErrMaxItemsExceeded, got %v", err)
+	}
+	if len(users) != 3 {
+		t.Errorf("Expected 3 users, got %v", len(users))
+	}
+
+	users, err = wordpress.Collect(wp.Users.All(ctx, &wordpress.UserListOptions{ListOptions: wordpress.ListOptions{Page: 4}}), 0)
+	if err != nil {
+		t.Errorf("Should not return error: %v", err)
+	}
+	if len(users) != 4 || users[0].ID != 7 {
+		t.Errorf("Expected users 7..10, got %v users", len(users))
+	}
+}
+
+func TestPagination_CollectReturnsError(t *testing.T) {
+	var requests int32
+	wp, ctx := initStubClient(t, servePages(2, 2, &requests))
+
+	_, err := wordpress.Collect(wp.Comments.All(ctx, &wordpress.CommentListOptions{ListOptions: wordpress.ListOptions{Page: 3}}), 0)
+	if err == nil {
+		t.Errorf("Should return error")
+	}
+}
+
+func TestPagination_AllWithListOptions(t *testing.T) {
+	var requests int32
+	pages := servePages(2, 2, &requests)
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wp-json/wp/v2/posts/1" {
+			w.Write([]byte(`{"id":1}`))
+			return
+		}
+		pages(w, r)
+	})
+	post, _, err := wp.Posts.Get(ctx, 1, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+
+	terms, err := wordpress.Collect(wp.Terms.All(ctx, "tag", nil), 0)
+	if err != nil || len(terms) != 4 {
+		t.Errorf("Expected 4 terms, got %v (%v)", len(terms), err)
+	}
+	terms, err = wordpress.Collect(wp.Terms.Category().All(ctx, &wordpress.ListOptions{Page: 2}), 0)
+	if err != nil || len(terms) != 2 || terms[0].ID != 3 {
+		t.Errorf("Expected terms 3..4, got %v (%v)", len(terms), err)
+	}
+	revisions, err := wordpress.Collect(post.Revisions().All(ctx, nil), 0)
+	if err != nil || len(revisions) != 4 {
+		t.Errorf("Expected 4 revisions, got %v (%v)", len(revisions), err)
+	}
+	postTerms, err := wordpress.Collect(post.Terms().All(ctx, "tag", nil), 0)
+	if err != nil || len(postTerms) != 4 {
+		t.Errorf("Expected 4 post terms, got %v (%v)", len(postTerms), err)
+	}
+	postTerms, err = wordpress.Collect(post.Terms().Tag().All(ctx, nil), 0)
+	if err != nil || len(postTerms) != 4 {
+		t.Errorf("Expected 4 post terms, got %v (%v)", len(postTerms), err)
+	}
+	if requests != 9 {
+		t.Errorf("Expected 9 requests, got %v", requests)
+	}
+}
@@ -0,0 +1,169 @@
+package wordpress
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultParallelWorkers is the number of pages fetched concurrently when ParallelOptions.Workers is zero.
+const DefaultParallelWorkers = 4
+
+// ParallelOptions configures the ListParallel methods.
+type ParallelOptions struct {
+	// Workers is the maximum number of pages fetched at the same time.
+	// Defaults to DefaultParallelWorkers.
+	Workers int
+
+	// ContinueOnError makes ListParallel fetch every page even if some of them
+	// fail. The items of the successful pages are returned in page order along
+	// with a PageErrors describing the failed ones. By default the first
+	// failing page cancels the remaining requests.
+	ContinueOnError bool
+}
+
+// PageError is returned from ListParallel when fetching a single page fails.
+type PageError struct {
+	Page int
+	Err  error
+}
+
+func (e *PageError) Error() string {
+	return fmt.Sprintf("page %d: %v", e.Page, e.Err)
+}
+
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+// PageErrors is returned from ListParallel with ContinueOnError set if one or more pages failed.
+type PageErrors []*PageError
+
+func (e PageErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, pageErr := range e {
+		msgs[i] = pageErr.Error()
+	}
+	return fmt.Sprintf("%d pages failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Unwrap allows errors.Is and errors.As to inspect the individual page errors.
+func (e PageErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, pageErr := range e {
+		errs[i] = pageErr
+	}
+	return errs
+}
+
+// listParallel fetches the first page of a collection, then fetches the
+// remaining pages reported by X-WP-TotalPages concurrently. Items are returned
+// in page order together with the response of the first page.
+func listParallel[T any, O any, P interface {
+	*O
+	pager
+}](ctx context.Context, opts P, list listFunc[T, P], popts *ParallelOptions) ([]T, *Response, error) {
+	if popts == nil {
+		popts = &ParallelOptions{}
+	}
+	workers := popts.Workers
+	if workers < 1 {
+		workers = DefaultParallelWorkers
+	}
+
+	var base O
+	if opts != nil {
+		base = *opts
+	}
+	first := P(&base).page()
+	if first < 1 {
+		first = 1
+	}
+	P(&base).setPage(first)
+
+	items, resp, err := list(ctx, P(&base))
+	if err != nil {
+		return nil, resp, &PageError{Page: first, Err: err}
+	}
+	if resp == nil || resp.TotalPages <= first {
+		return items, resp, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rest := resp.TotalPages - first
+	results := make([][]T, rest)
+	pageErrs := make([]*PageError, rest)
+	fetched := make([]bool, rest)
+	pages := make(chan int)
+
+	// the first error in time, which cancels the other pages unless ContinueOnError
+	var firstErr *PageError
+	var firstErrOnce sync.Once
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < rest; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				pageOpts := base
+				P(&pageOpts).setPage(page)
+				pageItems, _, err := list(ctx, P(&pageOpts))
+				if err != nil {
+					pageErr := &PageError{Page: page, Err: err}
+					pageErrs[page-first-1] = pageErr
+					firstErrOnce.Do(func() { firstErr = pageErr })
+					if !popts.ContinueOnError {
+						cancel()
+					}
+					continue
+				}
+				results[page-first-1] = pageItems
+				fetched[page-first-1] = true
+			}
+		}()
+	}
+
+feed:
+	for page := first + 1; page <= resp.TotalPages; page++ {
+		select {
+		case pages <- page:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(pages)
+	wg.Wait()
+
+	var failed PageErrors
+	for i, pageErr := range pageErrs {
+		if pageErr == nil && !fetched[i] {
+			// never requested because ctx was done
+			pageErr = &PageError{Page: first + 1 + i, Err: ctx.Err()}
+		}
+		if pageErr != nil {
+			failed = append(failed, pageErr)
+		}
+	}
+
+	if !popts.ContinueOnError {
+		// report the page that failed first rather than the ones canceled because of it
+		if firstErr != nil {
+			return nil, resp, firstErr
+		}
+		if len(failed) > 0 {
+			return nil, resp, failed[0]
+		}
+	}
+
+	for _, pageItems := range results {
+		items = append(items, pageItems...)
+	}
+	if len(failed) > 0 {
+		return items, resp, failed
+	}
+	return items, resp, nil
+}
@@ -0,0 +1,73 @@
+package wordpress_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestParallel_ReturnsItemsInPageOrder(t *testing.T) {
+	var requests int32
+	wp, ctx := initStubClient(t, servePages(7, 3, &requests))
+
+	posts, resp, err := wp.Posts.ListParallel(ctx, nil, &wordpress.ParallelOptions{Workers: 3})
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if resp.TotalRecords != 21 {
+		t.Errorf("Expected 21 total records, got %v", resp.TotalRecords)
+	}
+	if len(posts) != 21 {
+		t.Fatalf("Expected 21 posts, got %v", len(posts))
+	}
+	for i, post := range posts {
+		if post.ID != i+1 {
+			t.Fatalf("Expected post %v at position %v, got %v", i+1, i, post.ID)
+		}
+	}
+	if requests != 7 {
+		t.Errorf("Expected 7 requests, got %v", requests)
+	}
+}
+
+// failPage wraps handler to make the given page fail with a 500.
+func failPage(page string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == page {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"code":"internal_server_error","message":"boom","data":{"status":500}}`))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func TestParallel_FailFast(t *testing.T) {
+	var requests int32
+	wp, ctx := initStubClient(t, failPage("3", servePages(4, 2, &requests)))
+
+	tags, _, err := wp.Tags.ListParallel(ctx, nil, nil)
+	if tags != nil {
+		t.Errorf("Should not return items, got %v", len(tags))
+	}
+	var pageErr *wordpress.PageError
+	if !errors.As(err, &pageErr) || pageErr.Page != 3 {
+		t.Errorf("Expected error for page 3, got %v", err)
+	}
+}
+
+func TestParallel_ContinueOnError(t *testing.T) {
+	var requests int32
+	wp, ctx := initStubClient(t, failPage("2", servePages(3, 2, &requests)))
+
+	media, _, err := wp.Media.ListParallel(ctx, nil, &wordpress.ParallelOptions{ContinueOnError: true})
+	var pageErrs wordpress.PageErrors
+	if !errors.As(err, &pageErrs) || len(pageErrs) != 1 || pageErrs[0].Page != 2 {
+		t.Errorf("Expected errors for page 2 only, got %v", err)
+	}
+	if len(media) != 4 || media[0].ID != 1 || media[2].ID != 5 {
+		t.Errorf("Expected media of pages 1 and 3, got %v items", len(media))
+	}
+}
@@ -0,0 +1,202 @@
+package wordpress
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Optional is a field of a patch, such as PostPatch. Unlike the fields of the
+// entities, an Optional is sent whenever it is set, even to a false, zero or
+// empty value, or to null. The zero Optional is unset and is omitted.
+type Optional[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// Some returns an Optional set to value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, set: true}
+}
+
+// Null returns an Optional set to null, e.g. to reset a date to its default.
+func Null[T any]() Optional[T] {
+	return Optional[T]{set: true, null: true}
+}
+
+// Bool returns an Optional set to the boolean value.
+func Bool(value bool) Optional[bool] {
+	return Some(value)
+}
+
+// Int returns an Optional set to the integer value.
+func Int(value int) Optional[int] {
+	return Some(value)
+}
+
+// String returns an Optional set to the string value.
+func String(value string) Optional[string] {
+	return Some(value)
+}
+
+// Ints returns an Optional set to the list of ids. Without ids it is set to
+// an empty list, e.g. to remove all tags of a post.
+func Ints(ids ...int) Optional[[]int] {
+	if ids == nil {
+		ids = []int{}
+	}
+	return Some(ids)
+}
+
+// Strings returns an Optional set to the list of values. Without values it
+// is set to an empty list.
+func Strings(values ...string) Optional[[]string] {
+	if values == nil {
+		values = []string{}
+	}
+	return Some(values)
+}
+
+// Get returns the value of o. ok is false if o is unset or null.
+func (o Optional[T]) Get() (value T, ok bool) {
+	return o.value, o.set && !o.null
+}
+
+// IsSet reports whether o is set, possibly to null.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// IsNull reports whether o is set to null.
+func (o Optional[T]) IsNull() bool {
+	return o.null
+}
+
+// IsZero reports whether o is unset, so that it is omitted from requests.
+func (o Optional[T]) IsZero() bool {
+	return !o.set
+}
+
+// MarshalJSON encodes the value of o, or null.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.null {
+		return []byte("null"), nil
+	}
+	// encode through a pointer to use the pointer receiver methods of Time
+	return json.Marshal(&o.value)
+}
+
+// UnmarshalJSON sets o to the decoded value, or to null.
+func (o *Optional[T]) UnmarshalJSON(b []byte) error {
+	if isJSONNull(b) {
+		*o = Null[T]()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
+
+// PostPatch holds the fields of a post to change with PostsService.Patch.
+// Only the fields which are set are sent, e.g.
+//
+//	wp.Posts.Patch(ctx, id, wordpress.PostPatch{
+//		Sticky: wordpress.Bool(false),
+//		Tags:   wordpress.Ints(),
+//	})
+//
+// un-sticks the post and removes all of its tags. Title, Content and Excerpt
+// are set to their raw value. To change fields added by plugins, use
+// Client.Patch with a map.
+type PostPatch struct {
+	Author        Optional[int]     `json:"author,omitzero"`
+	Categories    Optional[[]int]   `json:"categories,omitzero"`
+	CommentStatus Optional[string]  `json:"comment_status,omitzero"`
+	Content       Optional[string]  `json:"content,omitzero"`
+	Date          Optional[Time]    `json:"date,omitzero"`
+	DateGMT       Optional[TimeGMT] `json:"date_gmt,omitzero"`
+	Excerpt       Optional[string]  `json:"excerpt,omitzero"`
+	FeaturedMedia Optional[int]     `json:"featured_media,omitzero"`
+	Format        Optional[string]  `json:"format,omitzero"`
+	Password      Optional[string]  `json:"password,omitzero"`
+	PingStatus    Optional[string]  `json:"ping_status,omitzero"`
+	Slug          Optional[string]  `json:"slug,omitzero"`
+	Status        Optional[string]  `json:"status,omitzero"`
+	Sticky        Optional[bool]    `json:"sticky,omitzero"`
+	Tags          Optional[[]int]   `json:"tags,omitzero"`
+	Template      Optional[string]  `json:"template,omitzero"`
+	Title         Optional[string]  `json:"title,omitzero"`
+	Meta          Meta              `json:"meta,omitzero"` // Meta keys to set, or to delete with Meta.Delete.
+}
+
+// PagePatch holds the fields of a page to change with PagesService.Patch.
+// Only the fields which are set are sent, see PostPatch.
+type PagePatch struct {
+	Author        Optional[int]     `json:"author,omitzero"`
+	CommentStatus Optional[string]  `json:"comment_status,omitzero"`
+	Content       Optional[string]  `json:"content,omitzero"`
+	Date          Optional[Time]    `json:"date,omitzero"`
+	DateGMT       Optional[TimeGMT] `json:"date_gmt,omitzero"`
+	Excerpt       Optional[string]  `json:"excerpt,omitzero"`
+	FeaturedMedia Optional[int]     `json:"featured_media,omitzero"`
+	MenuOrder     Optional[int]     `json:"menu_order,omitzero"`
+	Parent        Optional[int]     `json:"parent,omitzero"`
+	Password      Optional[string]  `json:"password,omitzero"`
+	PingStatus    Optional[string]  `json:"ping_status,omitzero"`
+	Slug          Optional[string]  `json:"slug,omitzero"`
+	Status        Optional[string]  `json:"status,omitzero"`
+	Template      Optional[string]  `json:"template,omitzero"`
+	Title         Optional[string]  `json:"title,omitzero"`
+	Meta          Meta              `json:"meta,omitzero"` // Meta keys to set, or to delete with Meta.Delete.
+}
+
+// CommentPatch holds the fields of a comment to change with
+// CommentsService.Patch. Only the fields which are set are sent, see PostPatch.
+type CommentPatch struct {
+	Author          Optional[int]     `json:"author,omitzero"`
+	AuthorEmail     Optional[string]  `json:"author_email,omitzero"`
+	AuthorIP        Optional[string]  `json:"author_ip,omitzero"`
+	AuthorName      Optional[string]  `json:"author_name,omitzero"`
+	AuthorURL       Optional[string]  `json:"author_url,omitzero"`
+	AuthorUserAgent Optional[string]  `json:"author_user_agent,omitzero"`
+	Content         Optional[string]  `json:"content,omitzero"`
+	Date            Optional[Time]    `json:"date,omitzero"`
+	DateGMT         Optional[TimeGMT] `json:"date_gmt,omitzero"`
+	Parent          Optional[int]     `json:"parent,omitzero"`
+	Post            Optional[int]     `json:"post,omitzero"`
+	Status          Optional[string]  `json:"status,omitzero"`
+	Meta            Meta              `json:"meta,omitzero"` // Meta keys to set, or to delete with Meta.Delete.
+}
+
+// UserPatch holds the fields of a user to change with UsersService.Patch.
+// Only the fields which are set are sent, see PostPatch.
+type UserPatch struct {
+	Description Optional[string]   `json:"description,omitzero"`
+	Email       Optional[string]   `json:"email,omitzero"`
+	FirstName   Optional[string]   `json:"first_name,omitzero"`
+	LastName    Optional[string]   `json:"last_name,omitzero"`
+	Locale      Optional[string]   `json:"locale,omitzero"`
+	Name        Optional[string]   `json:"name,omitzero"`
+	Nickname    Optional[string]   `json:"nickname,omitzero"`
+	Password    Optional[string]   `json:"password,omitzero"`
+	Roles       Optional[[]string] `json:"roles,omitzero"`
+	Slug        Optional[string]   `json:"slug,omitzero"`
+	URL         Optional[string]   `json:"url,omitzero"`
+	Username    Optional[string]   `json:"username,omitzero"`
+	Meta        Meta               `json:"meta,omitzero"` // Meta keys to set, or to delete with Meta.Delete.
+}
+
+// Patch changes only the given fields of an item on the WordPress REST API,
+// sending content with the PATCH method.
+func (c *Client) Patch(ctx context.Context, url string, content interface{}, result interface{}) (*Response, error) {
+	req, err := c.NewRequest(http.MethodPatch, url, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(ctx, req, &result)
+}
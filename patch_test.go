@@ -0,0 +1,98 @@
+package wordpress_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestPostsService_Patch(t *testing.T) {
+	var method, body string
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.Write([]byte(`{"id":7,"sticky":false,"tags":[]}`))
+	})
+
+	var meta wordpress.Meta
+	meta.Delete("subtitle")
+	post, resp, err := wp.Posts.Patch(ctx, 7, wordpress.PostPatch{
+		Sticky:        wordpress.Bool(false),
+		Tags:          wordpress.Ints(),
+		FeaturedMedia: wordpress.Int(0),
+		Excerpt:       wordpress.String(""),
+		DateGMT:       wordpress.Null[wordpress.TimeGMT](),
+		Meta:          meta,
+	})
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || post.ID != 7 {
+		t.Errorf("Unexpected response %v for post %v", resp.Status, post.ID)
+	}
+	if method != http.MethodPatch {
+		t.Errorf("Expected PATCH, got %v", method)
+	}
+	expected := `{"date_gmt":null,"excerpt":"","featured_media":0,"sticky":false,"tags":[],"meta":{"subtitle":null}}`
+	if body != expected+"\n" {
+		t.Errorf("Expected body %v, got %v", expected, body)
+	}
+	if post.Revisions() == nil {
+		t.Errorf("Expected patched post to have its service set")
+	}
+}
+
+func TestPatch_OmitsUnsetFields(t *testing.T) {
+	var bodies []string
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.Write([]byte(`{"id":1}`))
+	})
+
+	date := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	if _, _, err := wp.Pages.Patch(ctx, 1, wordpress.PagePatch{
+		MenuOrder: wordpress.Int(0),
+		Date:      wordpress.Some(wordpress.Time{Time: date}),
+	}); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if _, _, err := wp.Comments.Patch(ctx, 1, wordpress.CommentPatch{}); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if _, _, err := wp.Users.Patch(ctx, 1, wordpress.UserPatch{Roles: wordpress.Strings("editor")}); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+
+	expected := []string{
+		`{"date":"2024-03-01T10:00:00","menu_order":0}`,
+		`{}`,
+		`{"roles":["editor"]}`,
+	}
+	for i, body := range bodies {
+		if body != expected[i]+"\n" {
+			t.Errorf("Expected body %v, got %v", expected[i], body)
+		}
+	}
+}
+
+func TestOptional_JSON(t *testing.T) {
+	var patch wordpress.PostPatch
+	if err := json.Unmarshal([]byte(`{"sticky":false,"author":null}`), &patch); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if sticky, ok := patch.Sticky.Get(); !ok || sticky {
+		t.Errorf("Expected sticky to be set to false")
+	}
+	if !patch.Author.IsSet() || !patch.Author.IsNull() {
+		t.Errorf("Expected author to be set to null")
+	}
+	if patch.Tags.IsSet() {
+		t.Errorf("Expected tags to be unset")
+	}
+}
@@ -0,0 +1,129 @@
+package wordpress
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// PostTypeService provides access to the entities of a custom post type, such
+// as an "event" or "product" type registered with show_in_rest. T is the
+// entity type; it usually embeds Post and adds the custom fields of the type:
+//
+//	type Event struct {
+//		wordpress.Post
+//		Venue string `json:"venue,omitempty"`
+//	}
+//
+//	events := wordpress.NewPostTypeService[Event](client, "events")
+//	event, _, err := events.Get(ctx, 42, nil)
+//
+// The entities are not tied to the service, since the methods of the embedded
+// Post refer to the posts route: its Save, Delete and Reload return
+// ErrNotFetched, and its Revisions and Terms return nil. Use Update, Delete and
+// Revisions of the service instead.
+type PostTypeService[T any] struct {
+	client   *Client
+	restBase string
+}
+
+// NewPostTypeService returns a PostTypeService for the post type served at
+// the wp/v2 route restBase, e.g. "events".
+func NewPostTypeService[T any](client *Client, restBase string) *PostTypeService[T] {
+	return &PostTypeService[T]{client: client, restBase: restBase}
+}
+
+// ResolvePostTypeService returns a PostTypeService for the post type with the
+// given slug, looking up its rest_base with Types.Get.
+func ResolvePostTypeService[T any](ctx context.Context, client *Client, slug string) (*PostTypeService[T], *Response, error) {
+	postType, resp, err := client.Types.Get(ctx, slug, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+	if postType.RestNamespace != "" && postType.RestNamespace != apiPathPrefix[1:] {
+		return nil, resp, fmt.Errorf("post type %v is served in namespace %v, not %v", slug, postType.RestNamespace, apiPathPrefix[1:])
+	}
+	restBase := postType.RestBase
+	if restBase == "" {
+		restBase = slug
+	}
+	return NewPostTypeService[T](client, restBase), resp, nil
+}
+
+// RestBase returns the route of the post type, relative to wp/v2.
+func (c *PostTypeService[T]) RestBase() string {
+	return c.restBase
+}
+
+// List returns a list of entities.
+func (c *PostTypeService[T]) List(ctx context.Context, opts *PostListOptions) ([]*T, *Response, error) {
+	u, err := c.client.AddOptions(c.restBase, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entities := []*T{}
+	resp, err := c.client.Do(ctx, req, &entities)
+	if err != nil {
+		return nil, resp, err
+	}
+	return entities, resp, nil
+}
+
+// All returns an iterator over all entities matching opts, fetching one page after another
+// as the iteration advances.
+func (c *PostTypeService[T]) All(ctx context.Context, opts *PostListOptions) iter.Seq2[*T, error] {
+	return allPages(ctx, opts, c.List)
+}
+
+// ListParallel returns all entities matching opts. The remaining pages are fetched concurrently
+// once the first page has reported the total number of pages.
+func (c *PostTypeService[T]) ListParallel(ctx context.Context, opts *PostListOptions, popts *ParallelOptions) ([]*T, *Response, error) {
+	return listParallel(ctx, opts, c.List, popts)
+}
+
+// Create creates a new entity.
+func (c *PostTypeService[T]) Create(ctx context.Context, newEntity *T) (*T, *Response, error) {
+	var created T
+	resp, err := c.client.Create(ctx, c.restBase, newEntity, &created)
+	return &created, resp, err
+}
+
+// Get returns a single entity for the given id.
+func (c *PostTypeService[T]) Get(ctx context.Context, id int, params interface{}) (*T, *Response, error) {
+	var entity T
+	entityURL := fmt.Sprintf("%v/%v", c.restBase, id)
+	resp, err := c.client.Get(ctx, entityURL, params, &entity)
+	return &entity, resp, err
+}
+
+// Update updates a single entity with the given id.
+func (c *PostTypeService[T]) Update(ctx context.Context, id int, entity *T) (*T, *Response, error) {
+	var updated T
+	entityURL := fmt.Sprintf("%v/%v", c.restBase, id)
+	resp, err := c.client.Update(ctx, entityURL, entity, &updated)
+	return &updated, resp, err
+}
+
+// Delete removes the entity with the given id.
+func (c *PostTypeService[T]) Delete(ctx context.Context, id int, params interface{}) (*T, *Response, error) {
+	var deleted T
+	entityURL := fmt.Sprintf("%v/%v", c.restBase, id)
+	resp, err := c.client.Delete(ctx, entityURL, params, &deleted)
+	return &deleted, resp, err
+}
+
+// Revisions gets the revisions of the entity with the given id. The post type
+// must support revisions.
+func (c *PostTypeService[T]) Revisions(id int) *RevisionsService {
+	return &RevisionsService{
+		Service:    Service{Client: c.client},
+		parentType: c.restBase,
+		url:        fmt.Sprintf("%v/%v/%v", c.restBase, id, "revisions"),
+	}
+}
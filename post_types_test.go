@@ -0,0 +1,78 @@
+package wordpress_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+type event struct {
+	wordpress.Post
+	Venue string `json:"venue,omitempty"`
+}
+
+func TestPostTypeService(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /wp-json/wp/v2/types/event":
+			w.Write([]byte(`{"slug":"event","rest_base":"events","rest_namespace":"wp/v2"}`))
+		case "GET /wp-json/wp/v2/events":
+			w.Header().Set("X-WP-Total", "1")
+			w.Header().Set("X-WP-TotalPages", "1")
+			w.Write([]byte(`[{"id":1,"slug":"launch","venue":"Berlin"}]`))
+		case "POST /wp-json/wp/v2/events":
+			var created event
+			json.NewDecoder(r.Body).Decode(&created)
+			created.ID = 2
+			json.NewEncoder(w).Encode(created)
+		case "GET /wp-json/wp/v2/events/2/revisions":
+			w.Write([]byte(`[{"id":3,"parent":2}]`))
+		case "DELETE /wp-json/wp/v2/events/9":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"rest_post_invalid_id","message":"Invalid post ID."}`))
+		default:
+			t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+		}
+	})
+
+	events, _, err := wordpress.ResolvePostTypeService[event](ctx, wp, "event")
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if events.RestBase() != "events" {
+		t.Errorf("Expected rest_base events, got %q", events.RestBase())
+	}
+
+	all, err := wordpress.Collect(events.All(ctx, nil), 0)
+	if err != nil || len(all) != 1 || all[0].Venue != "Berlin" || all[0].Slug != "launch" {
+		t.Errorf("Unexpected events %+v (%v)", all, err)
+	}
+
+	created, _, err := events.Create(ctx, &event{Venue: "Paris"})
+	if err != nil || created.ID != 2 || created.Venue != "Paris" {
+		t.Errorf("Unexpected created event %+v (%v)", created, err)
+	}
+
+	// the embedded post is not tied to the posts route
+	if _, err := created.Save(ctx); !errors.Is(err, wordpress.ErrNotFetched) {
+		t.Errorf("Expected ErrNotFetched from the embedded Post, got %v", err)
+	}
+	if _, err := all[0].Delete(ctx, true); !errors.Is(err, wordpress.ErrNotFetched) {
+		t.Errorf("Expected ErrNotFetched from the embedded Post, got %v", err)
+	}
+	if created.Revisions() != nil || created.Terms() != nil {
+		t.Errorf("Expected no revisions and terms services of the embedded Post")
+	}
+
+	revisions, _, err := events.Revisions(created.ID).List(ctx, nil)
+	if err != nil || len(revisions) != 1 || revisions[0].Parent != 2 {
+		t.Errorf("Unexpected revisions %+v (%v)", revisions, err)
+	}
+
+	if _, _, err := events.Delete(ctx, 9, nil); !errors.Is(err, wordpress.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
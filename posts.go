@@ -3,6 +3,7 @@ package wordpress
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log"
 )
 
@@ -46,21 +47,22 @@ type RenderedString struct {
 // Post represents a WordPress post.
 type Post struct {
 	collection *PostsService
+	tracker
 
 	Author        int            `json:"author,omitempty"`
 	Categories    []int          `json:"categories,omitempty"`
 	CommentStatus string         `json:"comment_status,omitempty"`
-	Content       RenderedString `json:"content,omitempty"`
-	Date          Time           `json:"date,omitempty"`
-	DateGMT       TimeGMT        `json:"date_gmt,omitempty"`
-	Excerpt       RenderedString `json:"excerpt,omitempty"`
+	Content       RenderedString `json:"content,omitempty,omitzero"`
+	Date          Time           `json:"date,omitempty,omitzero"`
+	DateGMT       TimeGMT        `json:"date_gmt,omitempty,omitzero"`
+	Excerpt       RenderedString `json:"excerpt,omitempty,omitzero"`
 	FeaturedMedia int            `json:"featured_media,omitempty"`
 	Format        string         `json:"format,omitempty"`
-	GUID          RenderedString `json:"guid,omitempty"`
+	GUID          RenderedString `json:"guid,omitempty,omitzero"`
 	ID            int            `json:"id,omitempty"`
 	Link          string         `json:"link,omitempty"`
-	Modified      Time           `json:"modified,omitempty"`
-	ModifiedGMT   TimeGMT        `json:"modified_gmt,omitempty"`
+	Modified      Time           `json:"modified,omitempty,omitzero"`
+	ModifiedGMT   TimeGMT        `json:"modified_gmt,omitempty,omitzero"`
 	Password      string         `json:"password,omitempty"`
 	PingStatus    string         `json:"ping_status,omitempty"`
 	Slug          string         `json:"slug,omitempty"`
@@ -69,14 +71,59 @@ type Post struct {
 	Subtitle      string         `json:"wps_subtitle,omitempty"`
 	Tags          []int          `json:"tags,omitempty"`
 	Template      string         `json:"template,omitempty"`
-	Title         RenderedString `json:"title,omitempty"`
+	Title         RenderedString `json:"title,omitempty,omitzero"`
 	Type          string         `json:"type,omitempty"`
+	Meta          Meta           `json:"meta,omitzero"` // Meta fields registered with show_in_rest.
+
+	Embedded *Embedded `json:"-"` // Decoded from _embedded when requested with Embed, never sent.
+	Links    Links     `json:"-"` // Decoded from _links, never sent.
+
+	// Extra holds the fields without a struct field, e.g. added by plugins.
+	Extra ExtraFields `json:"-"`
 }
 
 func (entity *Post) setService(c *PostsService) {
 	entity.collection = c
 }
 
+func (entity *Post) service() entityService[Post] {
+	if entity.collection == nil {
+		return nil
+	}
+	return entity.collection
+}
+
+func (entity *Post) base() snapshot {
+	return baseSnapshot[Post](&entity.tracker)
+}
+
+// IsDirty reports whether the post has changed since it was fetched.
+func (entity *Post) IsDirty() bool {
+	return len(entityChanges(entity)) > 0
+}
+
+// Changed returns the sorted JSON names of the fields changed since the post was fetched.
+func (entity *Post) Changed() []string {
+	return changedFields(entityChanges(entity))
+}
+
+// Save updates the post with the fields changed since it was fetched, and
+// refreshes it from the response. It sends no request if nothing has changed.
+func (entity *Post) Save(ctx context.Context) (*Response, error) {
+	return saveEntity(ctx, entity, entity.ID)
+}
+
+// Delete moves the post to the trash, or deletes it permanently if force is
+// set, and refreshes it from the response.
+func (entity *Post) Delete(ctx context.Context, force bool) (*Response, error) {
+	return deleteEntity(ctx, entity, entity.ID, deleteParams(force))
+}
+
+// Reload fetches the post again, discarding unsaved changes.
+func (entity *Post) Reload(ctx context.Context) (*Response, error) {
+	return reloadEntity(ctx, entity, entity.ID)
+}
+
 // Revisions gets the revisions of a single post.
 func (entity *Post) Revisions() *RevisionsService {
 	if entity.collection == nil {
@@ -141,6 +188,18 @@ func (c *PostsService) List(ctx context.Context, opts *PostListOptions) ([]*Post
 	return posts, resp, nil
 }
 
+// All returns an iterator over all posts matching opts, fetching one page after another
+// as the iteration advances.
+func (c *PostsService) All(ctx context.Context, opts *PostListOptions) iter.Seq2[*Post, error] {
+	return allPages(ctx, opts, c.List)
+}
+
+// ListParallel returns all posts matching opts. The remaining pages are fetched concurrently
+// once the first page has reported the total number of pages.
+func (c *PostsService) ListParallel(ctx context.Context, opts *PostListOptions, popts *ParallelOptions) ([]*Post, *Response, error) {
+	return listParallel(ctx, opts, c.List, popts)
+}
+
 // Create creates a new post.
 func (c *PostsService) Create(ctx context.Context, newPost *Post) (*Post, *Response, error) {
 	var created Post
@@ -174,9 +233,26 @@ func (c *PostsService) Entity(id int) *Post {
 
 // Update updates a single post with the given id.
 func (c *PostsService) Update(ctx context.Context, id int, post *Post) (*Post, *Response, error) {
+	return c.update(ctx, id, post)
+}
+
+// update updates the post with the given id with body, e.g. a Post or only its changed fields.
+func (c *PostsService) update(ctx context.Context, id int, body interface{}) (*Post, *Response, error) {
+	var updated Post
+	entityURL := fmt.Sprintf("posts/%v", id)
+	resp, err := c.Client.Update(ctx, entityURL, body, &updated)
+
+	// set collection object for each entity which has sub-collection
+	updated.setService(c)
+
+	return &updated, resp, err
+}
+
+// Patch changes only the fields of the post with the given id which are set in patch.
+func (c *PostsService) Patch(ctx context.Context, id int, patch PostPatch) (*Post, *Response, error) {
 	var updated Post
 	entityURL := fmt.Sprintf("posts/%v", id)
-	resp, err := c.Client.Update(ctx, entityURL, post, &updated)
+	resp, err := c.Client.Patch(ctx, entityURL, patch, &updated)
 
 	// set collection object for each entity which has sub-collection
 	updated.setService(c)
@@ -3,6 +3,7 @@ package wordpress
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // PostsTerm represents a WordPress post post term.
@@ -33,6 +34,14 @@ func (c *PostsTermsService) List(ctx context.Context, taxonomy string, params in
 	return terms, resp, err
 }
 
+// All returns an iterator over all post terms of taxonomy matching opts, fetching one page
+// after another as the iteration advances.
+func (c *PostsTermsService) All(ctx context.Context, taxonomy string, opts *ListOptions) iter.Seq2[*PostsTerm, error] {
+	return allPages(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]*PostsTerm, *Response, error) {
+		return c.List(ctx, taxonomy, opts)
+	})
+}
+
 // Tag returns the tags of a post.
 func (c *PostsTermsService) Tag() *PostsTermsTaxonomyService {
 	return &PostsTermsTaxonomyService{
@@ -65,6 +74,14 @@ func (c *PostsTermsTaxonomyService) List(ctx context.Context, params interface{}
 	return terms, resp, err
 }
 
+// All returns an iterator over all post terms matching opts, fetching one page after another
+// as the iteration advances.
+func (c *PostsTermsTaxonomyService) All(ctx context.Context, opts *ListOptions) iter.Seq2[*PostsTerm, error] {
+	return allPages(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]*PostsTerm, *Response, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // Create creates a new post term.
 func (c *PostsTermsTaxonomyService) Create(ctx context.Context, id int) (*PostsTerm, *Response, error) {
 	var created PostsTerm
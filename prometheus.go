@@ -0,0 +1,255 @@
+package wordpress
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultPrometheusBuckets are the request duration histogram buckets, in seconds, used when
+// PrometheusObserver.Buckets is nil.
+var DefaultPrometheusBuckets = []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
+// PrometheusObserver is an Observer that records request metrics and exposes
+// them in the Prometheus text exposition format, without depending on the
+// Prometheus client library. Serve it on a metrics endpoint, e.g.
+//
+//	metrics := wordpress.NewPrometheusObserver("wordpress")
+//	client.Observer = metrics
+//	http.Handle("/metrics", metrics)
+//
+// The following metrics are recorded, labeled by method and route template:
+// <namespace>_requests_total (also by status and error_class),
+// <namespace>_request_duration_seconds, <namespace>_response_bytes_total,
+// <namespace>_request_retries_total and <namespace>_requests_in_flight.
+//
+// It is safe for concurrent use by multiple goroutines.
+type PrometheusObserver struct {
+	// Namespace is the prefix of all metric names.
+	Namespace string
+
+	// Buckets are the upper bounds of the request duration histogram, in seconds,
+	// in increasing order. Defaults to DefaultPrometheusBuckets.
+	Buckets []float64
+
+	mu        sync.Mutex
+	requests  map[promRequestKey]float64
+	durations map[promRouteKey]*promHistogram
+	bytes     map[promRouteKey]float64
+	retries   map[promRouteKey]float64
+	inFlight  map[promRouteKey]float64
+}
+
+type promRouteKey struct {
+	method, route string
+}
+
+type promRequestKey struct {
+	promRouteKey
+	status, errorClass string
+}
+
+type promHistogram struct {
+	counts []uint64 // per bucket, not cumulative; the last one is +Inf
+	sum    float64
+	count  uint64
+}
+
+// NewPrometheusObserver returns a PrometheusObserver using namespace as the metric name prefix.
+func NewPrometheusObserver(namespace string) *PrometheusObserver {
+	return &PrometheusObserver{Namespace: namespace}
+}
+
+// RequestStarted implements the Observer interface.
+func (o *PrometheusObserver) RequestStarted(ctx context.Context, info *RequestInfo) context.Context {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.init()
+	o.inFlight[promRouteKey{info.Method, info.Route}]++
+	return ctx
+}
+
+// RequestFinished implements the Observer interface.
+func (o *PrometheusObserver) RequestFinished(ctx context.Context, stats *RequestStats) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.init()
+
+	route := promRouteKey{stats.Method, stats.Route}
+	status := ""
+	if stats.StatusCode != 0 {
+		status = strconv.Itoa(stats.StatusCode)
+	}
+	o.inFlight[route]--
+	o.requests[promRequestKey{route, status, stats.ErrorClass}]++
+	o.bytes[route] += float64(stats.Bytes)
+	o.retries[route] += float64(stats.Retries)
+
+	buckets := o.buckets()
+	h := o.durations[route]
+	if h == nil {
+		h = &promHistogram{counts: make([]uint64, len(buckets)+1)}
+		o.durations[route] = h
+	}
+	seconds := stats.Duration.Seconds()
+	i := sort.SearchFloat64s(buckets, seconds)
+	h.counts[i]++
+	h.sum += seconds
+	h.count++
+}
+
+// init allocates the metric maps. o.mu must be held.
+func (o *PrometheusObserver) init() {
+	if o.requests != nil {
+		return
+	}
+	o.requests = make(map[promRequestKey]float64)
+	o.durations = make(map[promRouteKey]*promHistogram)
+	o.bytes = make(map[promRouteKey]float64)
+	o.retries = make(map[promRouteKey]float64)
+	o.inFlight = make(map[promRouteKey]float64)
+}
+
+func (o *PrometheusObserver) buckets() []float64 {
+	if o.Buckets != nil {
+		return o.Buckets
+	}
+	return DefaultPrometheusBuckets
+}
+
+// WriteTo writes all metrics to w in the Prometheus text exposition format.
+func (o *PrometheusObserver) WriteTo(w io.Writer) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	name := func(metric string) string {
+		if o.Namespace == "" {
+			return metric
+		}
+		return o.Namespace + "_" + metric
+	}
+
+	metric := name("requests_total")
+	fmt.Fprintf(cw, "# HELP %s Total number of WordPress API requests.\n# TYPE %s counter\n", metric, metric)
+	requestKeys := make([]promRequestKey, 0, len(o.requests))
+	for k := range o.requests {
+		requestKeys = append(requestKeys, k)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		a, b := requestKeys[i], requestKeys[j]
+		if a.promRouteKey != b.promRouteKey {
+			return a.promRouteKey.less(b.promRouteKey)
+		}
+		if a.status != b.status {
+			return a.status < b.status
+		}
+		return a.errorClass < b.errorClass
+	})
+	for _, k := range requestKeys {
+		fmt.Fprintf(cw, "%s{%s,status=%s,error_class=%s} %s\n",
+			metric, k.labels(), promQuote(k.status), promQuote(k.errorClass), promFloat(o.requests[k]))
+	}
+
+	metric = name("request_duration_seconds")
+	fmt.Fprintf(cw, "# HELP %s Duration of WordPress API requests, including retries.\n# TYPE %s histogram\n", metric, metric)
+	buckets := o.buckets()
+	for _, k := range sortedRouteKeys(o.durations) {
+		h := o.durations[k]
+		var cumulative uint64
+		for i, upper := range buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(cw, "%s_bucket{%s,le=%s} %d\n", metric, k.labels(), promQuote(promFloat(upper)), cumulative)
+		}
+		fmt.Fprintf(cw, "%s_bucket{%s,le=\"+Inf\"} %d\n", metric, k.labels(), h.count)
+		fmt.Fprintf(cw, "%s_sum{%s} %s\n", metric, k.labels(), promFloat(h.sum))
+		fmt.Fprintf(cw, "%s_count{%s} %d\n", metric, k.labels(), h.count)
+	}
+
+	for _, m := range []struct {
+		metric, help, typ string
+		values            map[promRouteKey]float64
+	}{
+		{"response_bytes_total", "Total number of response body bytes read.", "counter", o.bytes},
+		{"request_retries_total", "Total number of retried attempts.", "counter", o.retries},
+		{"requests_in_flight", "Number of WordPress API requests in flight.", "gauge", o.inFlight},
+	} {
+		metric = name(m.metric)
+		fmt.Fprintf(cw, "# HELP %s %s\n# TYPE %s %s\n", metric, m.help, metric, m.typ)
+		for _, k := range sortedRouteKeys(m.values) {
+			fmt.Fprintf(cw, "%s{%s} %s\n", metric, k.labels(), promFloat(m.values[k]))
+		}
+	}
+
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (o *PrometheusObserver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	o.WriteTo(w) // nolint: errcheck
+}
+
+func (k promRouteKey) labels() string {
+	return fmt.Sprintf("method=%s,route=%s", promQuote(k.method), promQuote(k.route))
+}
+
+func (k promRouteKey) less(other promRouteKey) bool {
+	if k.route != other.route {
+		return k.route < other.route
+	}
+	return k.method < other.method
+}
+
+func sortedRouteKeys[V any](m map[promRouteKey]V) []promRouteKey {
+	keys := make([]promRouteKey, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+	return keys
+}
+
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// promQuote quotes a label value, escaping backslashes, double quotes and line feeds.
+func promQuote(s string) string {
+	return `"` + promLabelEscaper.Replace(s) + `"`
+}
+
+func promFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// countingWriter counts the bytes written and keeps the first error.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
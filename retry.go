@@ -0,0 +1,224 @@
+package wordpress
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Default values used by RetryPolicy when the corresponding field is left zero.
+const (
+	DefaultRetryMaxAttempts = 3
+	DefaultRetryMinBackoff  = 500 * time.Millisecond
+	DefaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// DefaultRetryStatusCodes are the HTTP status codes retried when RetryPolicy.StatusCodes is nil.
+var DefaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy configures how Client.Do retries failed requests.
+// A nil *RetryPolicy on Client disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Defaults to DefaultRetryMaxAttempts.
+	MaxAttempts int
+
+	// MinBackoff is the wait before the first retry. It is doubled for every
+	// further attempt, up to MaxBackoff, and randomized with jitter. Waits
+	// requested with Retry-After are limited to MaxBackoff as well.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// StatusCodes are the response status codes that trigger a retry.
+	// Defaults to DefaultRetryStatusCodes.
+	StatusCodes []int
+
+	// RetryError reports whether a transport error should be retried.
+	// Defaults to retrying timeouts, refused/reset connections and unexpected EOFs.
+	RetryError func(err error) bool
+
+	// RetryNonIdempotent allows retrying POST, PUT, PATCH and non-forced DELETE requests.
+	// By default only GET, HEAD, OPTIONS and DELETE with force=true are retried.
+	RetryNonIdempotent bool
+
+	// IgnoreRetryAfter disables honoring the Retry-After response header.
+	IgnoreRetryAfter bool
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts
+	}
+	return DefaultRetryMaxAttempts
+}
+
+func (p *RetryPolicy) minBackoff() time.Duration {
+	if p.MinBackoff > 0 {
+		return p.MinBackoff
+	}
+	return DefaultRetryMinBackoff
+}
+
+func (p *RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff > 0 {
+		return p.MaxBackoff
+	}
+	return DefaultRetryMaxBackoff
+}
+
+// allowsMethod reports whether req may be sent more than once.
+func (p *RetryPolicy) allowsMethod(req *http.Request) bool {
+	if p.RetryNonIdempotent {
+		return true
+	}
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	case "DELETE":
+		return isForced(req.URL.Query())
+	}
+	return false
+}
+
+func (p *RetryPolicy) retryStatus(code int) bool {
+	codes := p.StatusCodes
+	if codes == nil {
+		codes = DefaultRetryStatusCodes
+	}
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) retryError(err error) bool {
+	if p.RetryError != nil {
+		return p.RetryError(err)
+	}
+	return isTransientError(err)
+}
+
+// backoff returns the wait before the given retry (1 for the first retry).
+// A Retry-After header on resp takes precedence over the computed backoff,
+// but is limited to MaxBackoff as well.
+func (p *RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	max := p.maxBackoff()
+	if resp != nil && !p.IgnoreRetryAfter {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if d > max {
+				d = max
+			}
+			return d
+		}
+	}
+
+	d := p.minBackoff()
+	for i := 1; i < retry && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+
+	// equal jitter: wait between d/2 and d
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// isTransientError reports whether err is a transport error that is likely to
+// succeed when the request is sent again.
+func isTransientError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return false
+}
+
+// send sends req through the underlying http.Client, retrying it according to
+// c.RetryPolicy. Request bodies are rebuilt with req.GetBody before every retry;
+// requests with a body that cannot be rebuilt are sent only once.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	policy := c.RetryPolicy
+	if policy == nil || !policy.allowsMethod(req) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return c.roundTrip(ctx, req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.roundTrip(ctx, req)
+		if attempt >= policy.maxAttempts() || ctx.Err() != nil {
+			return resp, err
+		}
+		if err != nil && !policy.retryError(err) {
+			return resp, err
+		}
+		if err == nil && !policy.retryStatus(resp.StatusCode) {
+			return resp, err
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if resp != nil {
+			// nolint: errcheck
+			io.CopyN(ioutil.Discard, resp.Body, 512)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
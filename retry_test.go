@@ -0,0 +1,168 @@
+package wordpress_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestRetry_RetriesStatusCodes(t *testing.T) {
+	var calls int32
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[{"id":1}]`))
+	})
+	wp.RetryPolicy = &wordpress.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+
+	posts, _, err := wp.Posts.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Errorf("Expected 1 post, got %v", len(posts))
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %v", calls)
+	}
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`{"code":"bad_gateway","message":"Bad Gateway","data":{"status":502}}`))
+	})
+	wp.RetryPolicy = &wordpress.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+
+	_, resp, err := wp.Posts.List(ctx, nil)
+	if err == nil {
+		t.Errorf("Should return error")
+	}
+	if resp == nil || resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected 502 response, got %v", resp)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 attempts, got %v", calls)
+	}
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	var calls int32
+	var first time.Time
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if time.Since(first) < time.Second {
+			t.Errorf("Retried before Retry-After elapsed: %v", time.Since(first))
+		}
+		w.Write([]byte(`{"id":1}`))
+	})
+	wp.RetryPolicy = &wordpress.RetryPolicy{MinBackoff: time.Millisecond}
+
+	if _, _, err := wp.Posts.Get(ctx, 1, nil); err != nil {
+		t.Errorf("Should not return error: %v", err)
+	}
+}
+
+func TestRetry_LimitsRetryAfter(t *testing.T) {
+	var calls int32
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	})
+	wp.RetryPolicy = &wordpress.RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, _, err := wp.Posts.Get(ctx, 1, nil); err != nil {
+		t.Errorf("Should not return error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 attempts, got %v", calls)
+	}
+}
+
+func TestRetry_SkipsNonIdempotentMethods(t *testing.T) {
+	var calls int32
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	wp.RetryPolicy = &wordpress.RetryPolicy{MinBackoff: time.Millisecond}
+
+	p := factoryPost()
+	wp.Posts.Create(ctx, &p)
+	if calls != 1 {
+		t.Errorf("POST should not be retried, got %v attempts", calls)
+	}
+
+	wp.Posts.Delete(ctx, 1, "force=false")
+	if calls != 2 {
+		t.Errorf("DELETE with force=false should not be retried, got %v attempts", calls-1)
+	}
+	wp.Posts.Delete(ctx, 1, "force=true")
+	if calls != 2+wordpress.DefaultRetryMaxAttempts {
+		t.Errorf("DELETE with force=true should be retried, got %v attempts", calls-2)
+	}
+}
+
+func TestRetry_RebuildsMultipartBody(t *testing.T) {
+	var calls int32
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Attempt %v: missing multipart file: %v", calls, err)
+		} else {
+			file.Close()
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":7}`))
+	})
+	wp.RetryPolicy = &wordpress.RetryPolicy{MinBackoff: time.Millisecond, RetryNonIdempotent: true}
+
+	media, _, err := wp.Media.Create(ctx, &wordpress.MediaUploadOptions{
+		Filename:    "test.txt",
+		ContentType: "text/plain",
+		Data:        []byte("hello"),
+	})
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if media.ID != 7 || calls != 2 {
+		t.Errorf("Expected media 7 after 2 attempts, got %v after %v", media.ID, calls)
+	}
+}
+
+func TestRetry_StopsOnContextCancel(t *testing.T) {
+	wp, _ := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	wp.RetryPolicy = &wordpress.RetryPolicy{MaxAttempts: 10, MinBackoff: time.Hour, MaxBackoff: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err := wp.Posts.List(ctx, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
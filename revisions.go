@@ -3,22 +3,23 @@ package wordpress
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // Revision represents a WordPress page/post revision.
 type Revision struct {
 	ID          int            `json:"id,omitempty"`
 	Author      int            `json:"author,omitempty"`
-	Date        Time           `json:"date,omitempty"`
-	DateGMT     TimeGMT        `json:"date_gmt,omitempty"`
-	GUID        RenderedString `json:"guid,omitempty"`
-	Modified    Time           `json:"modified,omitempty"`
-	ModifiedGMT TimeGMT        `json:"modified_gmt,omitempty"`
+	Date        Time           `json:"date,omitempty,omitzero"`
+	DateGMT     TimeGMT        `json:"date_gmt,omitempty,omitzero"`
+	GUID        RenderedString `json:"guid,omitempty,omitzero"`
+	Modified    Time           `json:"modified,omitempty,omitzero"`
+	ModifiedGMT TimeGMT        `json:"modified_gmt,omitempty,omitzero"`
 	Parent      int            `json:"parent,omitempty"`
 	Slug        string         `json:"slug,omitempty"`
-	Title       RenderedString `json:"title,omitempty"`
-	Content     RenderedString `json:"content,omitempty"`
-	Excerpt     RenderedString `json:"excerpt,omitempty"`
+	Title       RenderedString `json:"title,omitempty,omitzero"`
+	Content     RenderedString `json:"content,omitempty,omitzero"`
+	Excerpt     RenderedString `json:"excerpt,omitempty,omitzero"`
 }
 
 // RevisionsService provides access to the revision related functions in the WordPress REST API.
@@ -36,6 +37,14 @@ func (c *RevisionsService) List(ctx context.Context, params interface{}) ([]*Rev
 	return revisions, resp, err
 }
 
+// All returns an iterator over all revisions matching opts, fetching one page after another
+// as the iteration advances.
+func (c *RevisionsService) All(ctx context.Context, opts *ListOptions) iter.Seq2[*Revision, error] {
+	return allPages(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]*Revision, *Response, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // Get returns a single revision for the given id.
 func (c *RevisionsService) Get(ctx context.Context, id int, params interface{}) (*Revision, *Response, error) {
 	var revision Revision
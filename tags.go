@@ -3,6 +3,7 @@ package wordpress
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // Tag represents a WordPress page/post tag.
@@ -14,6 +15,10 @@ type Tag struct {
 	Name        string `json:"name,omitempty"`
 	Slug        string `json:"slug,omitempty"`
 	Taxonomy    string `json:"taxonomy,omitempty"`
+	Meta        Meta   `json:"meta,omitzero"` // Meta fields registered with show_in_rest.
+
+	// Extra holds the fields without a struct field, e.g. added by plugins.
+	Extra ExtraFields `json:"-"`
 }
 
 // TagsService provides access to the Tag related functions in the WordPress REST API.
@@ -39,6 +44,18 @@ func (c *TagsService) List(ctx context.Context, opts *TagListOptions) ([]*Tag, *
 	return tags, resp, nil
 }
 
+// All returns an iterator over all tags matching opts, fetching one page after another
+// as the iteration advances.
+func (c *TagsService) All(ctx context.Context, opts *TagListOptions) iter.Seq2[*Tag, error] {
+	return allPages(ctx, opts, c.List)
+}
+
+// ListParallel returns all tags matching opts. The remaining pages are fetched concurrently
+// once the first page has reported the total number of pages.
+func (c *TagsService) ListParallel(ctx context.Context, opts *TagListOptions, popts *ParallelOptions) ([]*Tag, *Response, error) {
+	return listParallel(ctx, opts, c.List, popts)
+}
+
 // Create creates a new tag.
 func (c *TagsService) Create(ctx context.Context, newTag *Tag) (*Tag, *Response, error) {
 	var created Tag
@@ -7,13 +7,15 @@ import (
 
 // Taxonomy represents a WordPress taxonomy.
 type Taxonomy struct {
-	Description  string                 `json:"description,omitempty"`
-	Hierarchical bool                   `json:"hierarchical,omitempty"`
-	Labels       map[string]interface{} `json:"labels,omitempty"`
-	Name         string                 `json:"name,omitempty"`
-	ShowCloud    bool                   `json:"show_cloud,omitempty"`
-	Slug         string                 `json:"slug,omitempty"`
-	Types        []string               `json:"types,omitempty"`
+	Description   string                 `json:"description,omitempty"`
+	Hierarchical  bool                   `json:"hierarchical,omitempty"`
+	Labels        map[string]interface{} `json:"labels,omitempty"`
+	Name          string                 `json:"name,omitempty"`
+	ShowCloud     bool                   `json:"show_cloud,omitempty"`
+	Slug          string                 `json:"slug,omitempty"`
+	Types         []string               `json:"types,omitempty"`
+	RestBase      string                 `json:"rest_base,omitempty"`
+	RestNamespace string                 `json:"rest_namespace,omitempty"` // WordPress 5.9+
 }
 
 // TaxonomiesService provides access to the Taxonomies related functions in the WordPress REST API.
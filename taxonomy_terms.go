@@ -0,0 +1,212 @@
+package wordpress
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+)
+
+// TaxonomyService provides access to the terms of any taxonomy, such as a
+// custom "genre" taxonomy registered with show_in_rest.
+type TaxonomyService struct {
+	client   *Client
+	restBase string
+}
+
+// Taxonomy returns the service for the terms of the taxonomy served at the
+// wp/v2 route restBase, e.g. "genres".
+func (c *Client) Taxonomy(restBase string) *TaxonomyService {
+	return &TaxonomyService{client: c, restBase: restBase}
+}
+
+// ResolveTaxonomy returns the service for the terms of the taxonomy with the
+// given slug, looking up its rest_base with Taxonomies.Get.
+func (c *Client) ResolveTaxonomy(ctx context.Context, slug string) (*TaxonomyService, *Response, error) {
+	taxonomy, resp, err := c.Taxonomies.Get(ctx, slug, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+	if taxonomy.RestNamespace != "" && taxonomy.RestNamespace != apiPathPrefix[1:] {
+		return nil, resp, fmt.Errorf("taxonomy %v is served in namespace %v, not %v", slug, taxonomy.RestNamespace, apiPathPrefix[1:])
+	}
+	restBase := taxonomy.RestBase
+	if restBase == "" {
+		restBase = slug
+	}
+	return c.Taxonomy(restBase), resp, nil
+}
+
+// RestBase returns the route of the taxonomy, relative to wp/v2. It is also
+// the name of the term-ID array of the taxonomy in posts, see PostsService.TermIDs.
+func (c *TaxonomyService) RestBase() string {
+	return c.restBase
+}
+
+// List returns a list of terms.
+func (c *TaxonomyService) List(ctx context.Context, opts *TermListOptions) ([]*Term, *Response, error) {
+	u, err := c.client.AddOptions(c.restBase, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	terms := []*Term{}
+	resp, err := c.client.Do(ctx, req, &terms)
+	if err != nil {
+		return nil, resp, err
+	}
+	return terms, resp, nil
+}
+
+// All returns an iterator over all terms matching opts, fetching one page after another
+// as the iteration advances.
+func (c *TaxonomyService) All(ctx context.Context, opts *TermListOptions) iter.Seq2[*Term, error] {
+	return allPages(ctx, opts, c.List)
+}
+
+// ListParallel returns all terms matching opts. The remaining pages are fetched concurrently
+// once the first page has reported the total number of pages.
+func (c *TaxonomyService) ListParallel(ctx context.Context, opts *TermListOptions, popts *ParallelOptions) ([]*Term, *Response, error) {
+	return listParallel(ctx, opts, c.List, popts)
+}
+
+// Children returns all direct children of the term with the given id, or the
+// top-level terms if parentID is 0. The taxonomy must be hierarchical.
+func (c *TaxonomyService) Children(ctx context.Context, parentID int, opts *TermListOptions) ([]*Term, error) {
+	var childOpts TermListOptions
+	if opts != nil {
+		childOpts = *opts
+	}
+	childOpts.Parent = &parentID
+	return Collect(c.All(ctx, &childOpts), 0)
+}
+
+// TermNode is a term with its children in a hierarchical taxonomy.
+type TermNode struct {
+	*Term
+	Children []*TermNode
+}
+
+// Tree returns all terms matching opts arranged by their parents. Terms whose
+// parent is not part of the result are returned as roots. Roots and children
+// keep the order of the listed terms.
+func (c *TaxonomyService) Tree(ctx context.Context, opts *TermListOptions) ([]*TermNode, error) {
+	terms, err := Collect(c.All(ctx, opts), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[int]*TermNode, len(terms))
+	for _, term := range terms {
+		nodes[term.ID] = &TermNode{Term: term}
+	}
+	var roots []*TermNode
+	for _, term := range terms {
+		node := nodes[term.ID]
+		if parent, ok := nodes[term.Parent]; ok && term.Parent != 0 && parent != node {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots, nil
+}
+
+// Create creates a new term.
+func (c *TaxonomyService) Create(ctx context.Context, newTerm *Term) (*Term, *Response, error) {
+	var created Term
+	resp, err := c.client.Create(ctx, c.restBase, newTerm, &created)
+	return &created, resp, err
+}
+
+// Get returns a single term for the given id.
+func (c *TaxonomyService) Get(ctx context.Context, id int, params interface{}) (*Term, *Response, error) {
+	var entity Term
+	entityURL := fmt.Sprintf("%v/%v", c.restBase, id)
+	resp, err := c.client.Get(ctx, entityURL, params, &entity)
+	return &entity, resp, err
+}
+
+// Update updates a single term with the given id.
+func (c *TaxonomyService) Update(ctx context.Context, id int, term *Term) (*Term, *Response, error) {
+	var updated Term
+	entityURL := fmt.Sprintf("%v/%v", c.restBase, id)
+	resp, err := c.client.Update(ctx, entityURL, term, &updated)
+	return &updated, resp, err
+}
+
+// Delete removes the term with the given id. Terms cannot be trashed, so
+// params must contain force, e.g. "force=true".
+func (c *TaxonomyService) Delete(ctx context.Context, id int, params interface{}) (*Term, *Response, error) {
+	var deleted Term
+	entityURL := fmt.Sprintf("%v/%v", c.restBase, id)
+	resp, err := c.client.Delete(ctx, entityURL, params, &deleted)
+	return &deleted, resp, err
+}
+
+// getTermIDs returns the term-ID array named taxonomyRestBase of the entity at entityURL.
+func getTermIDs(ctx context.Context, client *Client, entityURL string, taxonomyRestBase string) ([]int, *Response, error) {
+	var fields map[string]json.RawMessage
+	resp, err := client.Get(ctx, entityURL, &GetOptions{Fields: []string{taxonomyRestBase}}, &fields)
+	if err != nil {
+		return nil, resp, err
+	}
+	raw, ok := fields[taxonomyRestBase]
+	if !ok {
+		return nil, resp, fmt.Errorf("%v has no %v terms", entityURL, taxonomyRestBase)
+	}
+	ids := []int{}
+	err = json.Unmarshal(raw, &ids)
+	return ids, resp, err
+}
+
+// setTermIDs replaces the term-ID array named taxonomyRestBase of the entity at entityURL.
+func setTermIDs(ctx context.Context, client *Client, entityURL string, taxonomyRestBase string, termIDs []int) (*Response, error) {
+	if termIDs == nil {
+		// send an empty array to remove all terms
+		termIDs = []int{}
+	}
+	var updated json.RawMessage
+	return client.Update(ctx, entityURL, map[string][]int{taxonomyRestBase: termIDs}, &updated)
+}
+
+// TermIDs returns the IDs of the terms of the taxonomy served at
+// taxonomyRestBase, e.g. "genres", assigned to the post with the given id.
+func (c *PostsService) TermIDs(ctx context.Context, id int, taxonomyRestBase string) ([]int, *Response, error) {
+	return getTermIDs(ctx, c.Client, fmt.Sprintf("posts/%v", id), taxonomyRestBase)
+}
+
+// SetTermIDs replaces the terms of the taxonomy served at taxonomyRestBase
+// assigned to the post with the given id. Other fields are left untouched.
+func (c *PostsService) SetTermIDs(ctx context.Context, id int, taxonomyRestBase string, termIDs []int) (*Response, error) {
+	return setTermIDs(ctx, c.Client, fmt.Sprintf("posts/%v", id), taxonomyRestBase, termIDs)
+}
+
+// TermIDs returns the IDs of the terms of the taxonomy served at
+// taxonomyRestBase, e.g. "genres", assigned to the page with the given id.
+func (c *PagesService) TermIDs(ctx context.Context, id int, taxonomyRestBase string) ([]int, *Response, error) {
+	return getTermIDs(ctx, c.Client, fmt.Sprintf("pages/%v", id), taxonomyRestBase)
+}
+
+// SetTermIDs replaces the terms of the taxonomy served at taxonomyRestBase
+// assigned to the page with the given id. Other fields are left untouched.
+func (c *PagesService) SetTermIDs(ctx context.Context, id int, taxonomyRestBase string, termIDs []int) (*Response, error) {
+	return setTermIDs(ctx, c.Client, fmt.Sprintf("pages/%v", id), taxonomyRestBase, termIDs)
+}
+
+// TermIDs returns the IDs of the terms of the taxonomy served at
+// taxonomyRestBase, e.g. "genres", assigned to the entity with the given id.
+func (c *PostTypeService[T]) TermIDs(ctx context.Context, id int, taxonomyRestBase string) ([]int, *Response, error) {
+	return getTermIDs(ctx, c.client, fmt.Sprintf("%v/%v", c.restBase, id), taxonomyRestBase)
+}
+
+// SetTermIDs replaces the terms of the taxonomy served at taxonomyRestBase
+// assigned to the entity with the given id. Other fields are left untouched.
+func (c *PostTypeService[T]) SetTermIDs(ctx context.Context, id int, taxonomyRestBase string, termIDs []int) (*Response, error) {
+	return setTermIDs(ctx, c.client, fmt.Sprintf("%v/%v", c.restBase, id), taxonomyRestBase, termIDs)
+}
@@ -0,0 +1,102 @@
+package wordpress_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestTaxonomy_ResolveAndHierarchy(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wp-json/wp/v2/taxonomies/genre":
+			w.Write([]byte(`{"slug":"genre","hierarchical":true,"rest_base":"genres"}`))
+		case "/wp-json/wp/v2/genres":
+			q := r.URL.Query()
+			if q.Get("hide_empty") != "true" {
+				t.Errorf("Expected hide_empty filter, got %v", r.URL.RawQuery)
+			}
+			if q.Get("parent") == "0" {
+				w.Write([]byte(`[{"id":1,"name":"Fiction","parent":0}]`))
+				return
+			}
+			w.Write([]byte(`[{"id":1,"name":"Fiction","parent":0},{"id":2,"name":"Fantasy","parent":1},{"id":3,"name":"Epic","parent":2},{"id":4,"name":"Orphan","parent":99}]`))
+		default:
+			t.Errorf("Unexpected request %v", r.URL)
+		}
+	})
+
+	genres, _, err := wp.ResolveTaxonomy(ctx, "genre")
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if genres.RestBase() != "genres" {
+		t.Errorf("Expected rest_base genres, got %q", genres.RestBase())
+	}
+
+	opts := &wordpress.TermListOptions{HideEmpty: true}
+	top, err := genres.Children(ctx, 0, opts)
+	if err != nil || len(top) != 1 || top[0].Name != "Fiction" {
+		t.Errorf("Unexpected top-level terms %+v (%v)", top, err)
+	}
+	if opts.Parent != nil {
+		t.Errorf("Children should not modify opts")
+	}
+
+	tree, err := genres.Tree(ctx, opts)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if len(tree) != 2 || tree[0].Name != "Fiction" || tree[1].Name != "Orphan" {
+		t.Fatalf("Unexpected roots %+v", tree)
+	}
+	if len(tree[0].Children) != 1 || tree[0].Children[0].Name != "Fantasy" || tree[0].Children[0].Children[0].Name != "Epic" {
+		t.Errorf("Unexpected hierarchy %+v", tree[0].Children)
+	}
+}
+
+func TestTaxonomy_PostTermIDs(t *testing.T) {
+	var updateBody map[string]json.RawMessage
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /wp-json/wp/v2/posts/5", "GET /wp-json/wp/v2/pages/7", "GET /wp-json/wp/v2/events/6":
+			if got := r.URL.Query().Get("_fields"); got != "genres" {
+				t.Errorf("Unexpected _fields %q", got)
+			}
+			w.Write([]byte(`{"genres":[1,2]}`))
+		case "PUT /wp-json/wp/v2/pages/7", "PUT /wp-json/wp/v2/events/6":
+			json.NewDecoder(r.Body).Decode(&updateBody)
+			w.Write([]byte(`{"id":6}`))
+		default:
+			t.Errorf("Unexpected request %v %v", r.Method, r.URL)
+		}
+	})
+
+	ids, _, err := wp.Posts.TermIDs(ctx, 5, "genres")
+	if err != nil || len(ids) != 2 || ids[1] != 2 {
+		t.Errorf("Unexpected term IDs %v (%v)", ids, err)
+	}
+
+	if ids, _, err := wp.Pages.TermIDs(ctx, 7, "genres"); err != nil || len(ids) != 2 {
+		t.Errorf("Unexpected term IDs %v (%v)", ids, err)
+	}
+	if _, err := wp.Pages.SetTermIDs(ctx, 7, "genres", []int{3}); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if len(updateBody) != 1 || string(updateBody["genres"]) != "[3]" {
+		t.Errorf("Expected only the genres array, got %v", updateBody)
+	}
+
+	events := wordpress.NewPostTypeService[wordpress.Post](wp, "events")
+	if ids, _, err := events.TermIDs(ctx, 6, "genres"); err != nil || len(ids) != 2 {
+		t.Errorf("Unexpected term IDs %v (%v)", ids, err)
+	}
+	if _, err := events.SetTermIDs(ctx, 6, "genres", nil); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if len(updateBody) != 1 || string(updateBody["genres"]) != "[]" {
+		t.Errorf("Expected only an empty genres array, got %v", updateBody)
+	}
+}
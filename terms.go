@@ -3,18 +3,23 @@ package wordpress
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // Term represents a WordPress page/post term.
 type Term struct {
 	ID          int    `json:"id,omitempty"`
-	Count       int    `json:"integer,omitempty"`
+	Count       int    `json:"count,omitempty"`
 	Description string `json:"description,omitempty"`
 	Link        string `json:"link,omitempty"`
 	Name        string `json:"name"`
 	Slug        string `json:"slug,omitempty"`
 	Taxonomy    string `json:"taxonomy,omitempty"`
 	Parent      int    `json:"parent,omitempty"`
+	Meta        Meta   `json:"meta,omitzero"` // Meta fields registered with show_in_rest.
+
+	// Extra holds the fields without a struct field, e.g. added by plugins.
+	Extra ExtraFields `json:"-"`
 }
 
 // TermsService provides access to the Terms related functions in the WordPress REST API.
@@ -28,6 +33,14 @@ func (c *TermsService) List(ctx context.Context, taxonomy string, params interfa
 	return terms, resp, err
 }
 
+// All returns an iterator over all terms of taxonomy matching opts, fetching one page after
+// another as the iteration advances.
+func (c *TermsService) All(ctx context.Context, taxonomy string, opts *ListOptions) iter.Seq2[*Term, error] {
+	return allPages(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]*Term, *Response, error) {
+		return c.List(ctx, taxonomy, opts)
+	})
+}
+
 // Tag returns the terms taxonomy service configured for tags.
 func (c *TermsService) Tag() *TermsTaxonomyService {
 	return &TermsTaxonomyService{
@@ -60,6 +73,14 @@ func (c *TermsTaxonomyService) List(ctx context.Context, params interface{}) ([]
 	return terms, resp, err
 }
 
+// All returns an iterator over all terms matching opts, fetching one page after another
+// as the iteration advances.
+func (c *TermsTaxonomyService) All(ctx context.Context, opts *ListOptions) iter.Seq2[*Term, error] {
+	return allPages(ctx, opts, func(ctx context.Context, opts *ListOptions) ([]*Term, *Response, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // Create creates a new term.
 func (c *TermsTaxonomyService) Create(ctx context.Context, newTerm *Term) (*Term, *Response, error) {
 	var created Term
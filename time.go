@@ -36,6 +36,10 @@ const (
 )
 
 func unmarshalTimeJSON(t *time.Time, b []byte, loc *time.Location) error {
+	if string(b) == "null" {
+		// e.g. date_gmt of drafts; keep the zero value
+		return nil
+	}
 	if b[0] == '"' && b[len(b)-1] == '"' {
 		b = b[1 : len(b)-1]
 	}
@@ -0,0 +1,245 @@
+package wordpress
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrNotFetched is returned from the Save, Delete and Reload methods of
+// entities which were initialized manually instead of fetched from a service.
+var ErrNotFetched = errors.New("entity was not fetched from a service")
+
+// snapshot holds the JSON fields of an entity as it was fetched, to find the
+// fields changed since. Links and embedded resources are not part of it.
+type snapshot map[string]json.RawMessage
+
+// tracker holds the change tracking state of an entity. It is embedded in the
+// entities with change tracking, and fillExtra keeps the JSON of the entity in
+// it when it is decoded from a response. The snapshot is only taken from it
+// when the changes are first needed, so that entities which are never saved
+// are decoded only once.
+type tracker struct {
+	raw      json.RawMessage
+	original snapshot
+}
+
+func (t *tracker) changeTracker() *tracker {
+	return t
+}
+
+// track keeps raw, the JSON of the entity, discarding the previous snapshot.
+func (t *tracker) track(raw json.RawMessage) {
+	t.raw = raw
+	t.original = nil
+}
+
+// baseSnapshot returns the snapshot of the entity of type T tracked by t as it
+// was fetched, or nil if it was not decoded from a response.
+func baseSnapshot[T any](t *tracker) snapshot {
+	if t.original == nil && t.raw != nil {
+		var fetched T
+		if json.Unmarshal(t.raw, &fetched) == nil {
+			fillExtra(t.raw, &fetched)
+			t.original = takeSnapshot(&fetched)
+			t.raw = nil
+		}
+	}
+	return t.original
+}
+
+// entityService is implemented by the services of the entities with change tracking.
+type entityService[T any] interface {
+	Get(ctx context.Context, id int, params interface{}) (*T, *Response, error)
+	Delete(ctx context.Context, id int, params interface{}) (*T, *Response, error)
+	update(ctx context.Context, id int, body interface{}) (*T, *Response, error)
+}
+
+// trackedEntity is implemented by pointers to the entities with change tracking.
+type trackedEntity[T any] interface {
+	*T
+	changeTracker() *tracker
+	service() entityService[T] // nil if the entity was not fetched from a service
+}
+
+// entityChanges returns the JSON fields of entity changed since it was fetched.
+func entityChanges[T any, P trackedEntity[T]](entity P) map[string]json.RawMessage {
+	return baseSnapshot[T](entity.changeTracker()).changes(entity)
+}
+
+// saveEntity implements the Save methods of the entities: it updates the
+// entity with the given id with the changed fields and refreshes it from the response.
+func saveEntity[T any, P trackedEntity[T]](ctx context.Context, entity P, id int) (*Response, error) {
+	service := entity.service()
+	if service == nil {
+		return nil, ErrNotFetched
+	}
+	changes := entityChanges[T](entity)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	updated, resp, err := service.update(ctx, id, changes)
+	if err != nil {
+		return resp, err
+	}
+	*entity = *updated
+	return resp, nil
+}
+
+// deleteEntity implements the Delete methods of the entities, refreshing the
+// entity with the given id from the response.
+func deleteEntity[T any, P trackedEntity[T]](ctx context.Context, entity P, id int, params interface{}) (*Response, error) {
+	service := entity.service()
+	if service == nil {
+		return nil, ErrNotFetched
+	}
+	deleted, resp, err := service.Delete(ctx, id, params)
+	if err != nil {
+		return resp, err
+	}
+	*entity = *deleted
+	return resp, nil
+}
+
+// reloadEntity implements the Reload methods of the entities.
+func reloadEntity[T any, P trackedEntity[T]](ctx context.Context, entity P, id int) (*Response, error) {
+	service := entity.service()
+	if service == nil {
+		return nil, ErrNotFetched
+	}
+	reloaded, resp, err := service.Get(ctx, id, nil)
+	if err != nil {
+		return resp, err
+	}
+	*entity = *reloaded
+	return resp, nil
+}
+
+// takeSnapshot returns the JSON fields of entity, a pointer to a struct,
+// including its ExtraFields.
+func takeSnapshot(entity interface{}) snapshot {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil
+	}
+	var fields snapshot
+	if json.Unmarshal(data, &fields) != nil {
+		return nil
+	}
+	for name := range fields {
+		if strings.HasPrefix(name, "_") {
+			delete(fields, name)
+		}
+	}
+
+	if extra := extraField(reflect.ValueOf(entity).Elem()); extra.IsValid() {
+		for name, raw := range extra.Interface().(ExtraFields) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = raw
+			}
+		}
+	}
+	return fields
+}
+
+// changes returns the JSON fields of entity which differ from s. Fields which
+// are now omitted because they were reset to their zero value are included
+// with that value, e.g. false or an empty list.
+func (s snapshot) changes(entity interface{}) map[string]json.RawMessage {
+	current := takeSnapshot(entity)
+	changes := make(map[string]json.RawMessage)
+	for name, raw := range current {
+		if original, ok := s[name]; !ok || !bytes.Equal(original, raw) {
+			changes[name] = raw
+		}
+	}
+	for name := range s {
+		if _, ok := current[name]; ok {
+			continue
+		}
+		if raw, ok := zeroFieldJSON(reflect.ValueOf(entity).Elem(), name); ok {
+			changes[name] = raw
+		}
+	}
+	return changes
+}
+
+// changedFields returns the sorted names of the changed fields.
+func changedFields(changes map[string]json.RawMessage) []string {
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// zeroFieldJSON encodes the field of struct v with the given JSON name,
+// ignoring omitempty. Nil slices are encoded as empty lists, and zero values
+// of types with an IsZero method, such as Time, as null rather than e.g. the
+// year 1.
+func zeroFieldJSON(v reflect.Value, name string) (json.RawMessage, bool) {
+	field, ok := jsonField(v, name)
+	if !ok {
+		return nil, false
+	}
+	if field.Kind() == reflect.Slice && field.IsNil() {
+		return json.RawMessage("[]"), true
+	}
+	if zero, ok := field.Interface().(interface{ IsZero() bool }); ok && zero.IsZero() {
+		return json.RawMessage("null"), true
+	}
+	var data []byte
+	var err error
+	if field.CanAddr() {
+		data, err = json.Marshal(field.Addr().Interface())
+	} else {
+		data, err = json.Marshal(field.Interface())
+	}
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// jsonField returns the field of struct v with the given JSON name,
+// including fields promoted from embedded structs.
+func jsonField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		fieldName, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && fieldName == "" && f.Type.Kind() == reflect.Struct {
+			if field, ok := jsonField(v.Field(i), name); ok {
+				return field, true
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if fieldName == "" {
+			fieldName = f.Name
+		}
+		if fieldName == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// deleteParams returns the parameters of the Delete methods of entities.
+func deleteParams(force bool) interface{} {
+	if force {
+		return "force=true"
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package wordpress_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+func TestPost_SaveSendsChangedFields(t *testing.T) {
+	var requests []string
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+string(b))
+		if r.Method == "GET" {
+			w.Write([]byte(`{"id":3,"sticky":true,"tags":[1,2],"title":{"raw":"Old","rendered":"Old"},"status":"publish","meta":{"rating":4},"wpml_language":"en","_links":{"self":[{"href":"x"}]}}`))
+			return
+		}
+		w.Write([]byte(`{"id":3,"sticky":false,"tags":[],"title":{"raw":"New","rendered":"New"},"status":"publish","meta":{"rating":5},"wpml_language":"de"}`))
+	})
+
+	post, _, err := wp.Posts.Get(ctx, 3, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if post.IsDirty() {
+		t.Errorf("Expected fetched post not to be dirty, changed %v", post.Changed())
+	}
+
+	post.Sticky = false
+	post.Tags = nil
+	post.Title.Raw = "New"
+	post.Meta.SetInt("rating", 5)
+	post.Extra.Set("wpml_language", "de")
+	if !post.IsDirty() {
+		t.Fatalf("Expected changed post to be dirty")
+	}
+	expectedChanged := []string{"meta", "sticky", "tags", "title", "wpml_language"}
+	if changed := post.Changed(); !reflect.DeepEqual(changed, expectedChanged) {
+		t.Errorf("Expected changed %v, got %v", expectedChanged, changed)
+	}
+
+	if _, err := post.Save(ctx); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	expected := `PUT {"meta":{"rating":5},"sticky":false,"tags":[],"title":{"raw":"New","rendered":"Old"},"wpml_language":"de"}` + "\n"
+	if requests[1] != expected {
+		t.Errorf("Expected %v, got %v", expected, requests[1])
+	}
+	if post.IsDirty() || post.Title.Rendered != "New" {
+		t.Errorf("Expected saved post to be refreshed from the response, got %+v", post)
+	}
+
+	// nothing changed, nothing sent
+	resp, err := post.Save(ctx)
+	if err != nil || resp != nil || len(requests) != 2 {
+		t.Errorf("Expected no request for an unchanged post, got %v requests", len(requests))
+	}
+}
+
+func TestPost_ChangedBeforeFirstCheck(t *testing.T) {
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":1,"title":{"raw":"One","rendered":"One"},"wpml_language":"en"},{"id":2,"status":"draft"}]`))
+	})
+
+	posts, _, err := wp.Posts.List(ctx, nil)
+	if err != nil || len(posts) != 2 {
+		t.Fatalf("Expected 2 posts, got %v (%v)", len(posts), err)
+	}
+	// the snapshot is taken from the response, not from the changed post
+	posts[0].Title.Raw = "First"
+	posts[0].Extra.Set("wpml_language", "de")
+	if changed := posts[0].Changed(); !reflect.DeepEqual(changed, []string{"title", "wpml_language"}) {
+		t.Errorf("Expected title and wpml_language to be changed, got %v", changed)
+	}
+	if posts[1].IsDirty() {
+		t.Errorf("Expected unchanged post not to be dirty, changed %v", posts[1].Changed())
+	}
+}
+
+func TestPost_SaveClearsDate(t *testing.T) {
+	var body string
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+		}
+		w.Write([]byte(`{"id":3,"date":"2024-05-01T10:00:00","date_gmt":"2024-05-01T08:00:00","status":"future"}`))
+	})
+
+	post, _, err := wp.Posts.Get(ctx, 3, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	post.Date = wordpress.Time{}
+	post.DateGMT = wordpress.TimeGMT{}
+	if _, err := post.Save(ctx); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	expected := `{"date":null,"date_gmt":null}` + "\n"
+	if body != expected {
+		t.Errorf("Expected %v, got %v", expected, body)
+	}
+}
+
+func TestPost_DeleteAndReload(t *testing.T) {
+	var queries []string
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.Method+" "+r.URL.RawQuery)
+		switch r.Method {
+		case "DELETE":
+			w.Write([]byte(`{"id":3,"status":"trash"}`))
+		default:
+			w.Write([]byte(`{"id":3,"status":"publish"}`))
+		}
+	})
+
+	post, _, err := wp.Posts.Get(ctx, 3, nil)
+	if err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	post.Status = "draft"
+	if _, err := post.Reload(ctx); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if post.Status != "publish" || post.IsDirty() {
+		t.Errorf("Expected reload to discard changes, got status %v", post.Status)
+	}
+
+	if _, err := post.Delete(ctx, false); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+	if post.Status != "trash" {
+		t.Errorf("Expected trashed post, got status %v", post.Status)
+	}
+	if queries[2] != "DELETE " {
+		t.Errorf("Expected DELETE without force, got %v", queries[2])
+	}
+}
+
+func TestEntities_TrackChanges(t *testing.T) {
+	var bodies []string
+	wp, ctx := initStubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if r.Method == "PUT" {
+			bodies = append(bodies, string(b))
+		}
+		if r.Method == "DELETE" {
+			bodies = append(bodies, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"id":5,"name":"Editor","alt_text":"Alt","status":"approved","menu_order":2}`))
+	})
+
+	page, _, _ := wp.Pages.Get(ctx, 5, nil)
+	page.MenuOrder = 0
+	comment, _, _ := wp.Comments.Get(ctx, 5, nil)
+	comment.Status = "hold"
+	media, _, _ := wp.Media.Get(ctx, 5, nil)
+	media.AltText = ""
+	user, _, _ := wp.Users.Get(ctx, 5, nil)
+	user.Name = "Chief"
+
+	for _, save := range []func(context.Context) (*wordpress.Response, error){page.Save, comment.Save, media.Save, user.Save} {
+		if _, err := save(ctx); err != nil {
+			t.Fatalf("Should not return error: %v", err)
+		}
+	}
+	for _, reassign := range []int{1, 0} {
+		if _, err := user.Delete(ctx, reassign); err != nil {
+			t.Fatalf("Should not return error: %v", err)
+		}
+	}
+
+	expected := []string{
+		`{"menu_order":0}` + "\n",
+		`{"status":"hold"}` + "\n",
+		`{"alt_text":""}` + "\n",
+		`{"name":"Chief"}` + "\n",
+		`force=true&reassign=1`,
+		`force=true&reassign=false`,
+	}
+	if !reflect.DeepEqual(bodies, expected) {
+		t.Errorf("Expected %q, got %q", expected, bodies)
+	}
+}
+
+func TestEntities_NotFetched(t *testing.T) {
+	ctx := context.Background()
+	if _, err := (&wordpress.Post{ID: 1}).Save(ctx); !errors.Is(err, wordpress.ErrNotFetched) {
+		t.Errorf("Expected ErrNotFetched, got %v", err)
+	}
+	if _, err := (&wordpress.Comment{ID: 1}).Delete(ctx, true); !errors.Is(err, wordpress.ErrNotFetched) {
+		t.Errorf("Expected ErrNotFetched, got %v", err)
+	}
+	if _, err := (&wordpress.Media{ID: 1}).Reload(ctx); !errors.Is(err, wordpress.ErrNotFetched) {
+		t.Errorf("Expected ErrNotFetched, got %v", err)
+	}
+}
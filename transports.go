@@ -1,6 +1,18 @@
 package wordpress
 
-import "net/http"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+)
 
 // BasicAuthTransport is an http.RoundTripper that authenticates all requests
 // using HTTP Basic Authentication with the provided username and password.
@@ -38,9 +50,490 @@ func (t *BasicAuthTransport) Client() *http.Client {
 	return &http.Client{Jar: nil, Transport: t}
 }
 
+func (t *BasicAuthTransport) cacheIdentity() string {
+	return "basic " + t.Username + ":" + t.Password
+}
+
 func (t *BasicAuthTransport) transport() http.RoundTripper {
 	if t.Transport != nil {
 		return t.Transport
 	}
 	return http.DefaultTransport
 }
+
+// DefaultJWTTokenRoute is the REST API route of the token endpoint of the
+// common WordPress JWT authentication plugins.
+const DefaultJWTTokenRoute = "/jwt-auth/v1/token"
+
+// JWTAuthTransport is an http.RoundTripper that authenticates all requests
+// with a bearer token obtained from a JWT authentication plugin endpoint. The
+// token is requested with Username and Password on first use, cached, and
+// requested again once if WordPress rejects it as invalid or expired.
+// It is safe for concurrent use by multiple goroutines.
+type JWTAuthTransport struct {
+	Username string // WordPress username
+	Password string // WordPress password
+
+	// TokenURL is the token endpoint of the JWT plugin. A relative URL is
+	// resolved against the root of the site of the request being
+	// authenticated. Defaults to the DefaultJWTTokenRoute of the REST API the
+	// request is sent to, e.g. /blog/wp-json/jwt-auth/v1/token.
+	TokenURL string
+
+	// Transport is the underlying HTTP transport to use when making requests.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+
+	mu    sync.Mutex
+	token string
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *JWTAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Token(req.Context(), req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport().RoundTrip(withHeader(req, "Authorization", "Bearer "+token))
+	if err != nil || !rejectedWithCode(resp, "jwt_auth_invalid_token") {
+		return resp, err
+	}
+
+	body, ok := rewindBody(req)
+	if !ok {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	t.invalidate(token)
+	token, err = t.Token(req.Context(), req.URL)
+	if err != nil {
+		if body != nil {
+			body.Close()
+		}
+		return nil, err
+	}
+
+	req2 := withHeader(req, "Authorization", "Bearer "+token)
+	if body != nil {
+		req2.Body = body
+	}
+	return t.transport().RoundTrip(req2)
+}
+
+// Token returns the cached token, requesting a new one from the token endpoint
+// if there is none. ref, the URL of a REST API request, is used to resolve the
+// default or a relative TokenURL.
+func (t *JWTAuthTransport) Token(ctx context.Context, ref *url.URL) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" {
+		return t.token, nil
+	}
+
+	u := restRouteURL(ref, DefaultJWTTokenRoute)
+	if t.TokenURL != "" {
+		var err error
+		if u, err = siteRoot(ref).Parse(t.TokenURL); err != nil {
+			return "", err
+		}
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"username": t.Username,
+		"password": t.Password,
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return "", err
+	}
+
+	// jwt-authentication-for-wp-rest-api returns the token at the top level,
+	// jwt-auth (Useful Team) nests it in data
+	var tokenResp struct {
+		Token string `json:"token"`
+		Data  struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", err
+	}
+	t.token = tokenResp.Token
+	if t.token == "" {
+		t.token = tokenResp.Data.Token
+	}
+	if t.token == "" {
+		return "", fmt.Errorf("no token in response from %v", sanitizeURL(u))
+	}
+	return t.token, nil
+}
+
+// invalidate drops the cached token if it is still the given one, so that
+// concurrent requests rejected with the same token authenticate only once.
+func (t *JWTAuthTransport) invalidate(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token == token {
+		t.token = ""
+	}
+}
+
+// Client returns an *http.Client that makes requests that are authenticated
+// using a JWT bearer token.
+func (t *JWTAuthTransport) Client() *http.Client {
+	return &http.Client{Jar: nil, Transport: t}
+}
+
+func (t *JWTAuthTransport) cacheIdentity() string {
+	return "jwt " + t.TokenURL + " " + t.Username + ":" + t.Password
+}
+
+func (t *JWTAuthTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
+
+// siteRoot returns the root URL of the WordPress site of u, the URL of a REST
+// API request, e.g. https://example.com/blog/ for both
+// https://example.com/blog/wp-json/wp/v2/posts and
+// https://example.com/blog/?rest_route=/wp/v2/posts.
+func siteRoot(u *url.URL) *url.URL {
+	root := &url.URL{Scheme: u.Scheme, User: u.User, Host: u.Host, Path: "/"}
+	if u.Query().Has("rest_route") {
+		root.Path = u.Path[:strings.LastIndex(u.Path, "/")+1]
+	} else if i := strings.Index(u.Path+"/", "/wp-json/"); i >= 0 {
+		root.Path = u.Path[:i+1]
+	}
+	if root.Path == "" {
+		root.Path = "/"
+	}
+	return root
+}
+
+// restRouteURL returns the URL of the REST API route of the site of u, the
+// URL of a REST API request, using plain permalinks (?rest_route=) like u.
+func restRouteURL(u *url.URL, route string) *url.URL {
+	routeURL := siteRoot(u)
+	if u.Query().Has("rest_route") {
+		routeURL.RawQuery = "rest_route=" + route
+	} else {
+		routeURL.Path += "wp-json" + route
+	}
+	return routeURL
+}
+
+// rejectedWithCode reports whether resp is a 401 or 403 WordPress error with
+// the given code. The response body is restored so that it can still be read.
+func rejectedWithCode(resp *http.Response, code string) bool {
+	if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return false
+	}
+
+	var body struct {
+		Code string `json:"code"`
+	}
+	if json.Unmarshal(data, &body) != nil {
+		return false
+	}
+	return body.Code == code
+}
+
+// rewindBody returns a fresh copy of the body of req, so that req can be sent
+// again. ok is false if req has a body which cannot be rebuilt.
+func rewindBody(req *http.Request) (body io.ReadCloser, ok bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	return body, err == nil
+}
+
+// withHeader returns a copy of req with a deep copy of its headers and the
+// header key set to value.
+func withHeader(req *http.Request, key, value string) *http.Request {
+	// To set extra headers, we must make a copy of the Request so
+	// that we don't modify the Request we were given. This is required by the
+	// specification of http.RoundTripper.
+	req2 := new(http.Request)
+	*req2 = *req
+	req2.Header = make(http.Header, len(req.Header))
+	for k, s := range req.Header {
+		req2.Header[k] = append([]string(nil), s...)
+	}
+	req2.Header.Set(key, value)
+	return req2
+}
+
+// Default endpoints used by CookieAuthTransport, relative to the site root.
+const (
+	DefaultLoginPath = "wp-login.php"
+	DefaultNoncePath = "wp-admin/admin-ajax.php?action=rest-nonce"
+)
+
+// CookieAuthTransport is an http.RoundTripper that authenticates all requests
+// the way wp-admin does: with the WordPress login cookies and an X-WP-Nonce
+// header. If Username is set, it logs in through wp-login.php on first use;
+// otherwise Jar must already hold the cookies of a logged-in session. The REST
+// nonce is fetched from admin-ajax.php unless set in Nonce, and fetched again
+// once if WordPress rejects it with rest_cookie_invalid_nonce, logging in again
+// first if the session has expired.
+// It is safe for concurrent use by multiple goroutines.
+type CookieAuthTransport struct {
+	Username string // WordPress username
+	Password string // WordPress password
+
+	// LoginURL and NonceURL are the login form and the REST nonce endpoint.
+	// Relative URLs are resolved against the root of the site of the request
+	// being authenticated, e.g. https://example.com/blog/ for a site in a
+	// subdirectory. They default to DefaultLoginPath and DefaultNoncePath.
+	LoginURL string
+	NonceURL string
+
+	// Jar stores the session cookies. A new in-memory jar is created if nil.
+	Jar http.CookieJar
+
+	// Nonce is the REST nonce sent in the X-WP-Nonce header, e.g. the value of
+	// wpApiSettings.nonce of a logged-in browser session. It is fetched from
+	// NonceURL if empty.
+	Nonce string
+
+	// Transport is the underlying HTTP transport to use when making requests.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+
+	mu       sync.Mutex
+	loggedIn bool
+	jarOnce  sync.Once
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *CookieAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	nonce, err := t.nonce(req.Context(), req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.send(withHeader(req, "X-WP-Nonce", nonce))
+	if err != nil || !rejectedWithCode(resp, "rest_cookie_invalid_nonce") {
+		return resp, err
+	}
+
+	body, ok := rewindBody(req)
+	if !ok {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	t.invalidate(nonce)
+	nonce, err = t.nonce(req.Context(), req.URL)
+	if err != nil {
+		if body != nil {
+			body.Close()
+		}
+		return nil, err
+	}
+
+	req2 := withHeader(req, "X-WP-Nonce", nonce)
+	if body != nil {
+		req2.Body = body
+	}
+	return t.send(req2)
+}
+
+// Client returns an *http.Client that makes requests that are authenticated
+// using WordPress cookies and a REST nonce.
+func (t *CookieAuthTransport) Client() *http.Client {
+	return &http.Client{Jar: nil, Transport: t}
+}
+
+// send sends req with the cookies from the jar, and stores the cookies of the
+// response in the jar. req must be a copy owned by the transport.
+func (t *CookieAuthTransport) send(req *http.Request) (*http.Response, error) {
+	jar := t.jar()
+	for _, cookie := range jar.Cookies(req.URL) {
+		req.AddCookie(cookie)
+	}
+
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		jar.SetCookies(req.URL, cookies)
+	}
+	return resp, nil
+}
+
+// nonce returns the current REST nonce, logging in and fetching one if needed.
+// If the session has expired, it logs in again and fetches the nonce once more.
+func (t *CookieAuthTransport) nonce(ctx context.Context, ref *url.URL) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.Nonce != "" {
+		return t.Nonce, nil
+	}
+
+	wasLoggedIn := t.loggedIn
+	if t.Username != "" && !t.loggedIn {
+		if err := t.login(ctx, ref); err != nil {
+			return "", err
+		}
+		t.loggedIn = true
+	}
+
+	nonce, err := t.fetchNonce(ctx, ref)
+	if err != nil && t.Username != "" && wasLoggedIn {
+		// the session cookie has expired
+		if err := t.login(ctx, ref); err != nil {
+			t.loggedIn = false
+			return "", err
+		}
+		nonce, err = t.fetchNonce(ctx, ref)
+	}
+	if err != nil {
+		t.loggedIn = false
+		return "", err
+	}
+	t.Nonce = nonce
+	return nonce, nil
+}
+
+// fetchNonce fetches a REST nonce for the current session from NonceURL.
+func (t *CookieAuthTransport) fetchNonce(ctx context.Context, ref *url.URL) (string, error) {
+	nonceURL := t.NonceURL
+	if nonceURL == "" {
+		nonceURL = DefaultNoncePath
+	}
+	u, err := siteRoot(ref).Parse(nonceURL)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := t.send(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	// admin-ajax.php answers "0" or "-1" when the session is not logged in
+	nonce := strings.TrimSpace(string(data))
+	if resp.StatusCode != http.StatusOK || nonce == "" || nonce == "0" || nonce == "-1" {
+		return "", fmt.Errorf("could not get REST nonce from %v: %d %q", sanitizeURL(u), resp.StatusCode, nonce)
+	}
+	return nonce, nil
+}
+
+// login posts the credentials to the WordPress login form.
+func (t *CookieAuthTransport) login(ctx context.Context, ref *url.URL) error {
+	loginURL := t.LoginURL
+	if loginURL == "" {
+		loginURL = DefaultLoginPath
+	}
+	u, err := siteRoot(ref).Parse(loginURL)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{
+		"log":        {t.Username},
+		"pwd":        {t.Password},
+		"rememberme": {"forever"},
+		"testcookie": {"1"},
+		"wp-submit":  {"Log In"},
+	}
+	req, err := http.NewRequest("POST", u.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// wp-login.php refuses to log in if the browser does not accept its test cookie
+	req.AddCookie(&http.Cookie{Name: "wordpress_test_cookie", Value: "WP Cookie check"})
+
+	resp, err := t.send(req)
+	if err != nil {
+		return err
+	}
+	// nolint: errcheck
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	for _, cookie := range resp.Cookies() {
+		if strings.HasPrefix(cookie.Name, "wordpress_logged_in_") && cookie.Value != "" {
+			return nil
+		}
+	}
+	return fmt.Errorf("login to %v failed: %d", sanitizeURL(u), resp.StatusCode)
+}
+
+// invalidate drops the cached nonce if it is still the given one.
+func (t *CookieAuthTransport) invalidate(nonce string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Nonce == nonce {
+		t.Nonce = ""
+	}
+}
+
+func (t *CookieAuthTransport) jar() http.CookieJar {
+	t.jarOnce.Do(func() {
+		if t.Jar == nil {
+			// cookiejar.New only fails for invalid options
+			t.Jar, _ = cookiejar.New(nil)
+		}
+	})
+	return t.Jar
+}
+
+func (t *CookieAuthTransport) cacheIdentity() string {
+	if t.Username != "" {
+		return "cookie " + t.Username + ":" + t.Password
+	}
+	// the session is identified by the jar holding its cookies
+	return fmt.Sprintf("cookie %p", t.jar())
+}
+
+func (t *CookieAuthTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
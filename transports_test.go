@@ -0,0 +1,215 @@
+package wordpress_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/robbiet480/go-wordpress"
+)
+
+// jwtStub serves a JWT token endpoint issuing numbered tokens, and a posts
+// endpoint accepting only the latest token.
+func jwtStub(t *testing.T, tokenPath string) (*httptest.Server, *int32) {
+	var issued int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endpoint := r.URL.Path
+		if route := r.URL.Query().Get("rest_route"); route != "" {
+			endpoint += "?rest_route=" + route
+		}
+		if endpoint == tokenPath {
+			var creds struct {
+				Username string `json:"username"`
+				Password string `json:"password"`
+			}
+			json.NewDecoder(r.Body).Decode(&creds)
+			if creds.Username != "admin" || creds.Password != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"code":"[jwt_auth] incorrect_password","message":"Wrong password","data":{"status":403}}`))
+				return
+			}
+			n := atomic.AddInt32(&issued, 1)
+			fmt.Fprintf(w, `{"token":"token-%d","user_email":"admin@example.com"}`, n)
+			return
+		}
+		if r.Header.Get("Authorization") != fmt.Sprintf("Bearer token-%d", atomic.LoadInt32(&issued)) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"code":"jwt_auth_invalid_token","message":"Expired token","data":{"status":403}}`))
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+func TestJWTAuthTransport_ReauthenticatesOnInvalidToken(t *testing.T) {
+	server, issued := jwtStub(t, "/wp-json/jwt-auth/v1/token")
+	tp := &wordpress.JWTAuthTransport{Username: "admin", Password: "secret"}
+	wp, err := wordpress.NewClient(server.URL, tp.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, _, err := wp.Posts.Get(ctx, 1, nil); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+
+	// expire the cached token on the server side
+	atomic.AddInt32(issued, 1)
+
+	p := factoryPost()
+	if _, _, err := wp.Posts.Update(ctx, 1, &p); err != nil {
+		t.Fatalf("Should re-authenticate, got error: %v", err)
+	}
+	if *issued != 3 {
+		t.Errorf("Expected a new token to be issued, %v tokens issued", *issued)
+	}
+}
+
+func TestJWTAuthTransport_SiteInSubdirectory(t *testing.T) {
+	for _, nonPretty := range []bool{false, true} {
+		tokenPath := "/blog/wp-json/jwt-auth/v1/token"
+		if nonPretty {
+			tokenPath = "/blog/?rest_route=/jwt-auth/v1/token"
+		}
+		server, issued := jwtStub(t, tokenPath)
+		tp := &wordpress.JWTAuthTransport{Username: "admin", Password: "secret"}
+		wp, _ := wordpress.NewClient(server.URL+"/blog", tp.Client())
+		wp.NonPrettyPermalinks = nonPretty
+
+		if _, _, err := wp.Posts.Get(context.Background(), 1, nil); err != nil {
+			t.Errorf("Should not return error with token endpoint %v: %v", tokenPath, err)
+		}
+		if *issued != 1 {
+			t.Errorf("Expected a token from %v, %v tokens issued", tokenPath, *issued)
+		}
+	}
+}
+
+func TestJWTAuthTransport_ConcurrentRequestsShareToken(t *testing.T) {
+	server, issued := jwtStub(t, "/wp-json/simple-jwt/v1/token")
+	tp := &wordpress.JWTAuthTransport{Username: "admin", Password: "secret", TokenURL: "/wp-json/simple-jwt/v1/token"}
+	wp, _ := wordpress.NewClient(server.URL, tp.Client())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := wp.Posts.Get(context.Background(), 1, nil); err != nil {
+				t.Errorf("Should not return error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if *issued != 1 {
+		t.Errorf("Expected a single token, %v tokens issued", *issued)
+	}
+}
+
+func TestJWTAuthTransport_WrongPassword(t *testing.T) {
+	server, _ := jwtStub(t, "/wp-json/jwt-auth/v1/token")
+	tp := &wordpress.JWTAuthTransport{Username: "admin", Password: "wrong"}
+	wp, _ := wordpress.NewClient(server.URL, tp.Client())
+
+	if _, _, err := wp.Posts.Get(context.Background(), 1, nil); err == nil {
+		t.Errorf("Should return error")
+	}
+}
+
+// cookieStub serves wp-login.php, the rest-nonce ajax action and a posts
+// endpoint requiring the cookie of the current session and the current nonce,
+// for a site at root.
+func cookieStub(t *testing.T, root string) (server *httptest.Server, nonces, sessions *int32) {
+	nonces, sessions = new(int32), new(int32)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, _ := r.Cookie("wordpress_logged_in_abc")
+		loggedIn := session != nil && session.Value == fmt.Sprintf("admin|session%d", atomic.LoadInt32(sessions))
+		switch r.URL.Path {
+		case root + "wp-login.php":
+			if _, err := r.Cookie("wordpress_test_cookie"); err != nil || r.FormValue("log") != "admin" || r.FormValue("pwd") != "secret" {
+				w.Write([]byte(`<html>ERROR</html>`))
+				return
+			}
+			value := fmt.Sprintf("admin|session%d", atomic.AddInt32(sessions, 1))
+			http.SetCookie(w, &http.Cookie{Name: "wordpress_logged_in_abc", Value: value, Path: "/"})
+			w.Header().Set("Location", root+"wp-admin/")
+			w.WriteHeader(http.StatusFound)
+		case root + "wp-admin/admin-ajax.php":
+			if !loggedIn || r.URL.Query().Get("action") != "rest-nonce" {
+				w.Write([]byte("0"))
+				return
+			}
+			fmt.Fprintf(w, "nonce%d", atomic.AddInt32(nonces, 1))
+		default:
+			if !loggedIn || r.Header.Get("X-WP-Nonce") != fmt.Sprintf("nonce%d", atomic.LoadInt32(nonces)) {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"code":"rest_cookie_invalid_nonce","message":"Cookie check failed","data":{"status":403}}`))
+				return
+			}
+			w.Write([]byte(`{"id":1}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, nonces, sessions
+}
+
+func TestCookieAuthTransport_LoginAndNonceRefresh(t *testing.T) {
+	server, nonces, _ := cookieStub(t, "/")
+	tp := &wordpress.CookieAuthTransport{Username: "admin", Password: "secret"}
+	wp, _ := wordpress.NewClient(server.URL, tp.Client())
+	ctx := context.Background()
+
+	if _, _, err := wp.Posts.Get(ctx, 1, nil); err != nil {
+		t.Fatalf("Should not return error: %v", err)
+	}
+
+	// the nonce expires on the server side
+	atomic.AddInt32(nonces, 1)
+
+	p := factoryPost()
+	if _, _, err := wp.Posts.Create(ctx, &p); err != nil {
+		t.Fatalf("Should refresh the nonce, got error: %v", err)
+	}
+	if tp.Nonce != "nonce3" {
+		t.Errorf("Expected refreshed nonce nonce3, got %v", tp.Nonce)
+	}
+}
+
+func TestCookieAuthTransport_LoginFails(t *testing.T) {
+	server, _, _ := cookieStub(t, "/")
+	tp := &wordpress.CookieAuthTransport{Username: "admin", Password: "wrong"}
+	wp, _ := wordpress.NewClient(server.URL, tp.Client())
+
+	if _, _, err := wp.Posts.Get(context.Background(), 1, nil); err == nil {
+		t.Errorf("Should return error")
+	}
+}
+
+func TestCookieAuthTransport_LoginAgainOnExpiredSession(t *testing.T) {
+	server, _, sessions := cookieStub(t, "/blog/")
+	tp := &wordpress.CookieAuthTransport{Username: "admin", Password: "secret"}
+	wp, _ := wordpress.NewClient(server.URL+"/blog", tp.Client())
+	ctx := context.Background()
+
+	if _, _, err := wp.Posts.Get(ctx, 1, nil); err != nil {
+		t.Fatalf("Should log in to the site in the subdirectory, got error: %v", err)
+	}
+
+	// the session expires on the server side
+	atomic.AddInt32(sessions, 1)
+
+	if _, _, err := wp.Posts.Get(ctx, 1, nil); err != nil {
+		t.Fatalf("Should log in again within the same request, got error: %v", err)
+	}
+	if *sessions != 3 {
+		t.Errorf("Expected a second login, got %v sessions", *sessions)
+	}
+}
@@ -25,11 +25,14 @@ type TypeLabels struct {
 
 // Type represents a WordPress item type.
 type Type struct {
-	Description  string     `json:"description,omitempty"`
-	Hierarchical bool       `json:"hierarchical,omitempty"`
-	Name         string     `json:"name,omitempty"`
-	Slug         string     `json:"slug,omitempty"`
-	Labels       TypeLabels `json:"labels,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	Hierarchical  bool       `json:"hierarchical,omitempty"`
+	Name          string     `json:"name,omitempty"`
+	Slug          string     `json:"slug,omitempty"`
+	Labels        TypeLabels `json:"labels,omitempty,omitzero"`
+	Taxonomies    []string   `json:"taxonomies,omitempty"`
+	RestBase      string     `json:"rest_base,omitempty"`
+	RestNamespace string     `json:"rest_namespace,omitempty"` // WordPress 5.9+
 }
 
 // Types represents the assigned types for each item type.
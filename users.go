@@ -3,6 +3,7 @@ package wordpress
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // AvatarURLS returns different sizes of the users avatar.
@@ -14,9 +15,12 @@ type AvatarURLS struct {
 
 // User represents a WordPress user.
 type User struct {
+	collection *UsersService
+	tracker
+
 	ID                int                    `json:"id,omitempty"`
 	AvatarURL         string                 `json:"avatar_url,omitempty"`
-	AvatarURLs        AvatarURLS             `json:"avatar_urls,omitempty"`
+	AvatarURLs        AvatarURLS             `json:"avatar_urls,omitempty,omitzero"`
 	Capabilities      map[string]interface{} `json:"capabilities,omitempty"`
 	Description       string                 `json:"description,omitempty"`
 	Email             string                 `json:"email,omitempty"`
@@ -26,13 +30,61 @@ type User struct {
 	Link              string                 `json:"link,omitempty"`
 	Name              string                 `json:"name,omitempty"`
 	Nickname          string                 `json:"nickname,omitempty"`
-	RegisteredDate    Time                   `json:"registered_date,omitempty"`
+	RegisteredDate    Time                   `json:"registered_date,omitempty,omitzero"`
 	Roles             []string               `json:"roles,omitempty"`
 	Slug              string                 `json:"slug,omitempty"`
 	URL               string                 `json:"url,omitempty"`
 	Username          string                 `json:"username,omitempty"`
 	Password          string                 `json:"password,omitempty"`
 	Locale            string                 `json:"locale,omitempty"`
+	Meta              Meta                   `json:"meta,omitzero"` // Meta fields registered with show_in_rest.
+
+	// Extra holds the fields without a struct field, e.g. added by plugins.
+	Extra ExtraFields `json:"-"`
+}
+
+func (entity *User) setService(c *UsersService) {
+	entity.collection = c
+}
+
+func (entity *User) service() entityService[User] {
+	if entity.collection == nil {
+		return nil
+	}
+	return entity.collection
+}
+
+// IsDirty reports whether the user has changed since it was fetched.
+func (entity *User) IsDirty() bool {
+	return len(entityChanges(entity)) > 0
+}
+
+// Changed returns the sorted JSON names of the fields changed since the user was fetched.
+func (entity *User) Changed() []string {
+	return changedFields(entityChanges(entity))
+}
+
+// Save updates the user with the fields changed since it was fetched, and
+// refreshes it from the response. It sends no request if nothing has changed.
+func (entity *User) Save(ctx context.Context) (*Response, error) {
+	return saveEntity(ctx, entity, entity.ID)
+}
+
+// Delete deletes the user permanently, as users cannot be trashed, and
+// refreshes it from the response. The posts and links of the user are
+// reassigned to the user with the id reassign, or deleted along with the user
+// if reassign is 0, which is sent as reassign=false.
+func (entity *User) Delete(ctx context.Context, reassign int) (*Response, error) {
+	params := "force=true&reassign=false"
+	if reassign != 0 {
+		params = fmt.Sprintf("force=true&reassign=%v", reassign)
+	}
+	return deleteEntity(ctx, entity, entity.ID, params)
+}
+
+// Reload fetches the user again, discarding unsaved changes.
+func (entity *User) Reload(ctx context.Context) (*Response, error) {
+	return reloadEntity(ctx, entity, entity.ID)
 }
 
 // UsersService provides access to the Users related functions in the WordPress REST API.
@@ -43,6 +95,10 @@ func (c *UsersService) Me(ctx context.Context, params interface{}) (*User, *Resp
 	url := fmt.Sprintf("%v/me", "users")
 	var user User
 	resp, err := c.Client.Get(ctx, url, params, &user)
+
+	// set collection object to save, delete and reload the entity
+	user.setService(c)
+
 	return &user, resp, err
 }
 
@@ -64,13 +120,34 @@ func (c *UsersService) List(ctx context.Context, opts *UserListOptions) ([]*User
 		return nil, resp, err
 	}
 
+	// set collection object to save, delete and reload the entity
+	for _, entity := range users {
+		entity.setService(c)
+	}
+
 	return users, resp, nil
 }
 
+// All returns an iterator over all users matching opts, fetching one page after another
+// as the iteration advances.
+func (c *UsersService) All(ctx context.Context, opts *UserListOptions) iter.Seq2[*User, error] {
+	return allPages(ctx, opts, c.List)
+}
+
+// ListParallel returns all users matching opts. The remaining pages are fetched concurrently
+// once the first page has reported the total number of pages.
+func (c *UsersService) ListParallel(ctx context.Context, opts *UserListOptions, popts *ParallelOptions) ([]*User, *Response, error) {
+	return listParallel(ctx, opts, c.List, popts)
+}
+
 // Create creates a new user.
 func (c *UsersService) Create(ctx context.Context, newUser *User) (*User, *Response, error) {
 	var created User
 	resp, err := c.Client.Create(ctx, "users", newUser, &created)
+
+	// set collection object to save, delete and reload the entity
+	created.setService(c)
+
 	return &created, resp, err
 }
 
@@ -79,14 +156,39 @@ func (c *UsersService) Get(ctx context.Context, id int, params interface{}) (*Us
 	var entity User
 	entityURL := fmt.Sprintf("users/%v", id)
 	resp, err := c.Client.Get(ctx, entityURL, params, &entity)
+
+	// set collection object to save, delete and reload the entity
+	entity.setService(c)
+
 	return &entity, resp, err
 }
 
 // Update updates a single user with the given id.
 func (c *UsersService) Update(ctx context.Context, id int, user *User) (*User, *Response, error) {
+	return c.update(ctx, id, user)
+}
+
+// update updates the user with the given id with body, e.g. a User or only its changed fields.
+func (c *UsersService) update(ctx context.Context, id int, body interface{}) (*User, *Response, error) {
 	var updated User
 	entityURL := fmt.Sprintf("users/%v", id)
-	resp, err := c.Client.Update(ctx, entityURL, user, &updated)
+	resp, err := c.Client.Update(ctx, entityURL, body, &updated)
+
+	// set collection object to save, delete and reload the entity
+	updated.setService(c)
+
+	return &updated, resp, err
+}
+
+// Patch changes only the fields of the user with the given id which are set in patch.
+func (c *UsersService) Patch(ctx context.Context, id int, patch UserPatch) (*User, *Response, error) {
+	var updated User
+	entityURL := fmt.Sprintf("users/%v", id)
+	resp, err := c.Client.Patch(ctx, entityURL, patch, &updated)
+
+	// set collection object to save, delete and reload the entity
+	updated.setService(c)
+
 	return &updated, resp, err
 }
 
@@ -95,5 +197,9 @@ func (c *UsersService) Delete(ctx context.Context, id int, params interface{}) (
 	var deleted User
 	entityURL := fmt.Sprintf("users/%v", id)
 	resp, err := c.Client.Delete(ctx, entityURL, params, &deleted)
+
+	// set collection object to save, delete and reload the entity
+	deleted.setService(c)
+
 	return &deleted, resp, err
 }